@@ -0,0 +1,56 @@
+package http
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//Auth authorizes a request, it returns false once unauthorized request response has been written
+type Auth func(writer http.ResponseWriter, request *http.Request) bool
+
+//NewBasicAuth creates basic authentication with supplied credentials
+func NewBasicAuth(realm, username, password string) Auth {
+	return func(writer http.ResponseWriter, request *http.Request) bool {
+		user, pass, ok := request.BasicAuth()
+		if ok && secureEqual(user, username) && secureEqual(pass, password) {
+			return true
+		}
+		writer.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+		http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return false
+	}
+}
+
+//NewBearerAuth creates bearer token authentication accepting any of supplied tokens
+func NewBearerAuth(tokens ...string) Auth {
+	return func(writer http.ResponseWriter, request *http.Request) bool {
+		authorization := request.Header.Get("Authorization")
+		if strings.HasPrefix(authorization, "Bearer ") {
+			token := strings.TrimPrefix(authorization, "Bearer ")
+			for _, candidate := range tokens {
+				if secureEqual(token, candidate) {
+					return true
+				}
+			}
+		}
+		writer.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(writer, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return false
+	}
+}
+
+//NewWriteAuth wraps auth to authorize modifying requests only, GET and HEAD requests are served without authorization
+func NewWriteAuth(auth Auth) Auth {
+	return func(writer http.ResponseWriter, request *http.Request) bool {
+		if request.Method == http.MethodGet || request.Method == http.MethodHead {
+			return true
+		}
+		return auth(writer, request)
+	}
+}
+
+func secureEqual(value, expect string) bool {
+	return subtle.ConstantTimeCompare([]byte(value), []byte(expect)) == 1
+}
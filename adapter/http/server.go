@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/afs"
+	afile "github.com/viant/afs/file"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	//MethodMkcol represents collection (directory) creation method
+	MethodMkcol     = "MKCOL"
+	jsonContentType = "application/json"
+)
+
+//Server represents REST handler exposing afs.Service base URL:
+//GET reads a file (with range support) or lists a directory as nginx style json index,
+//HEAD returns resource headers, PUT uploads a file, MKCOL creates a directory and DELETE removes a resource,
+//POST uploads a file or, without content (afs http Create), creates a directory
+type Server struct {
+	fs      afs.Service
+	baseURL string
+	options []storage.Option
+	//Auth optional request authorization
+	Auth Auth
+	//Hide optional rules for resources that are neither listed nor accessible
+	Hide *matcher.Ignore
+}
+
+//listEntry represents json directory index entry, compatible with nginx autoindex_format json
+type listEntry struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Mtime string `json:"mtime"`
+	Size  int64  `json:"size,omitempty"`
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if s.Auth != nil && !s.Auth(writer, request) {
+		return
+	}
+	URLPath := path.Clean("/" + request.URL.Path)
+	URL := s.URL(URLPath)
+	ctx := request.Context()
+	if s.isHidden(ctx, URLPath, URL) {
+		http.NotFound(writer, request)
+		return
+	}
+	response := &responseWriter{ResponseWriter: writer}
+	writer = response
+	var err error
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		err = s.get(ctx, writer, request, URL)
+	case http.MethodPut:
+		err = s.upload(ctx, writer, request, URL)
+	case http.MethodPost:
+		if request.ContentLength == 0 {
+			if object, _ := s.fs.Object(ctx, URL, s.options...); object != nil && object.IsDir() {
+				writer.WriteHeader(http.StatusOK)
+				return
+			}
+			err = s.mkcol(ctx, writer, URL)
+			break
+		}
+		err = s.upload(ctx, writer, request, URL)
+	case MethodMkcol:
+		err = s.mkcol(ctx, writer, URL)
+	case http.MethodDelete:
+		if URLPath == "/" {
+			http.Error(writer, "base location can not be deleted", http.StatusForbidden)
+			return
+		}
+		err = s.delete(ctx, writer, URL)
+	default:
+		writer.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, MethodMkcol, http.MethodDelete}, ", "))
+		http.Error(writer, fmt.Sprintf("unsupported method: %v", request.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		log.Printf("failed to serve %v %v: %v", request.Method, URL, err)
+		if response.started {
+			return
+		}
+		status := s.errorStatus(ctx, URL, err)
+		http.Error(writer, http.StatusText(status), status)
+	}
+}
+
+//responseWriter tracks if response was started
+type responseWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.started = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.started = true
+	return w.ResponseWriter.Write(data)
+}
+
+//URL returns storage URL for supplied request path
+func (s *Server) URL(URLPath string) string {
+	URLPath = strings.Trim(URLPath, "/")
+	if URLPath == "" {
+		return s.baseURL
+	}
+	return url.Join(s.baseURL, URLPath)
+}
+
+func (s *Server) get(ctx context.Context, writer http.ResponseWriter, request *http.Request, URL string) error {
+	object, err := s.fs.Object(ctx, URL, s.options...)
+	if err != nil {
+		return err
+	}
+	if object.IsDir() {
+		return s.list(ctx, writer, request, object)
+	}
+	reader, err := s.fs.Open(ctx, object, s.options...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	content, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return ServeStream(writer, request, object, reader)
+	}
+	http.ServeContent(writer, request, object.Name(), object.ModTime(), content)
+	return nil
+}
+
+//list writes directory entries sorted by name
+func (s *Server) list(ctx context.Context, writer http.ResponseWriter, request *http.Request, dir storage.Object) error {
+	objects, err := s.fs.List(ctx, dir.URL(), s.options...)
+	if err != nil {
+		return err
+	}
+	_, dirPath := url.Base(dir.URL(), afile.Scheme)
+	_, basePath := url.Base(s.baseURL, afile.Scheme)
+	parent := "/" + strings.Trim(strings.TrimPrefix(dirPath, strings.TrimRight(basePath, "/")), "/")
+	var entries = make([]*listEntry, 0, len(objects))
+	for _, object := range objects {
+		if object.IsDir() && url.Equals(object.URL(), dir.URL()) {
+			continue
+		}
+		if s.Hide != nil && !s.Hide.Match(parent, object) {
+			continue
+		}
+		entry := &listEntry{Name: object.Name(), Type: "file", Mtime: object.ModTime().UTC().Format(http.TimeFormat), Size: object.Size()}
+		if object.IsDir() {
+			entry.Type = "directory"
+			entry.Size = 0
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	writer.Header().Set("Content-Type", jsonContentType)
+	writer.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
+	writer.Header().Set("Last-Modified", dir.ModTime().UTC().Format(http.TimeFormat))
+	if request.Method == http.MethodHead {
+		return nil
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+func (s *Server) upload(ctx context.Context, writer http.ResponseWriter, request *http.Request, URL string) error {
+	object, _ := s.fs.Object(ctx, URL, s.options...)
+	if object != nil && object.IsDir() {
+		http.Error(writer, fmt.Sprintf("%v is a directory", request.URL.Path), http.StatusConflict)
+		return nil
+	}
+	if err := s.fs.Upload(ctx, URL, afile.DefaultFileOsMode, request.Body, s.options...); err != nil {
+		return err
+	}
+	if object == nil {
+		writer.WriteHeader(http.StatusCreated)
+		return nil
+	}
+	writer.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) mkcol(ctx context.Context, writer http.ResponseWriter, URL string) error {
+	if exists, _ := s.fs.Exists(ctx, URL, s.options...); exists {
+		http.Error(writer, "resource already exists", http.StatusMethodNotAllowed)
+		return nil
+	}
+	if err := s.fs.Create(ctx, URL, afile.DefaultDirOsMode, true, s.options...); err != nil {
+		return err
+	}
+	writer.WriteHeader(http.StatusCreated)
+	return nil
+}
+
+func (s *Server) delete(ctx context.Context, writer http.ResponseWriter, URL string) error {
+	if exists, err := s.fs.Exists(ctx, URL, s.options...); err != nil || !exists {
+		http.Error(writer, "resource not found", http.StatusNotFound)
+		return nil
+	}
+	if err := s.fs.Delete(ctx, URL, s.options...); err != nil {
+		return err
+	}
+	writer.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+//isHidden returns true if request path or any of its parents matches hide rules, parents are matched as directories,
+//request path is matched as directory only if it is a directory
+func (s *Server) isHidden(ctx context.Context, URLPath, URL string) bool {
+	if s.Hide == nil {
+		return false
+	}
+	names := strings.Split(strings.Trim(URLPath, "/"), "/")
+	parent := "/"
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		isLast := i == len(names)-1
+		dirInfo := afile.NewInfo(name, 0, afile.DefaultDirOsMode, time.Now(), true)
+		if !isLast {
+			if !s.Hide.Match(parent, dirInfo) {
+				return true
+			}
+			parent = path.Join(parent, name)
+			continue
+		}
+		if !s.Hide.Match(parent, afile.NewInfo(name, 0, afile.DefaultFileOsMode, time.Now(), false)) {
+			return true
+		}
+		if !s.Hide.Match(parent, dirInfo) {
+			object, _ := s.fs.Object(ctx, URL, s.options...)
+			return object != nil && object.IsDir()
+		}
+	}
+	return false
+}
+
+//errorStatus returns 404 for not existing resources, 500 otherwise
+func (s *Server) errorStatus(ctx context.Context, URL string, err error) int {
+	if exists, e := s.fs.Exists(ctx, URL, s.options...); e == nil && !exists {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+//NewServer creates REST handler for supplied service base URL
+func NewServer(fs afs.Service, baseURL string, options ...storage.Option) *Server {
+	return &Server{fs: fs, baseURL: url.Normalize(baseURL, afile.Scheme), options: options}
+}
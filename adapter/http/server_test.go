@@ -0,0 +1,151 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	ahttp "github.com/viant/afs/http"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestServer_ServeHTTP(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/served"
+	for URL, content := range map[string]string{
+		baseURL + "/folder/a.txt":         "abcdef",
+		baseURL + "/folder/b.txt":         "xyz",
+		baseURL + "/secret/key":           "secret",
+		baseURL + "/folder/c.tmp":         "tmp",
+		baseURL + "/folder/d/e.go":        "package d",
+		baseURL + "/private/nested/x.txt": "private",
+	} {
+		if !assert.Nil(t, fs.Upload(ctx, URL, 0644, strings.NewReader(content))) {
+			return
+		}
+	}
+	server := NewServer(fs, baseURL)
+	server.Hide = &matcher.Ignore{Rules: []string{"secret", "*.tmp", "private/"}}
+	server.Auth = NewWriteAuth(NewBasicAuth("afs", "user", "pass"))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	var useCases = []struct {
+		description  string
+		method       string
+		URL          string
+		header       http.Header
+		auth         bool
+		body         string
+		expectStatus int
+		expect       string
+		notExpect    string
+	}{
+		{description: "get file", method: http.MethodGet, URL: "/folder/a.txt", expectStatus: http.StatusOK, expect: "abcdef"},
+		{description: "get range", method: http.MethodGet, URL: "/folder/a.txt", header: http.Header{"Range": []string{"bytes=2-3"}}, expectStatus: http.StatusPartialContent, expect: "cd"},
+		{description: "get suffix range", method: http.MethodGet, URL: "/folder/a.txt", header: http.Header{"Range": []string{"bytes=-2"}}, expectStatus: http.StatusPartialContent, expect: "ef"},
+		{description: "get open range", method: http.MethodGet, URL: "/folder/a.txt", header: http.Header{"Range": []string{"bytes=4-"}}, expectStatus: http.StatusPartialContent, expect: "ef"},
+		{description: "list folder", method: http.MethodGet, URL: "/folder/", expectStatus: http.StatusOK, expect: `"name":"b.txt"`},
+		{description: "hidden file", method: http.MethodGet, URL: "/secret/key", expectStatus: http.StatusNotFound},
+		{description: "hidden ext", method: http.MethodGet, URL: "/folder/c.tmp", expectStatus: http.StatusNotFound},
+		{description: "hidden nested directory", method: http.MethodGet, URL: "/private/nested/x.txt", expectStatus: http.StatusNotFound},
+		{description: "hidden directory", method: http.MethodGet, URL: "/private", expectStatus: http.StatusNotFound},
+		{description: "missing file", method: http.MethodGet, URL: "/folder/z.txt", expectStatus: http.StatusNotFound, expect: "Not Found", notExpect: "mem://"},
+		{description: "unauthorized put", method: http.MethodPut, URL: "/folder/new.txt", body: "new", expectStatus: http.StatusUnauthorized},
+		{description: "put new file", method: http.MethodPut, URL: "/folder/new.txt", auth: true, body: "new", expectStatus: http.StatusCreated},
+		{description: "put existing file", method: http.MethodPut, URL: "/folder/new.txt", auth: true, body: "updated", expectStatus: http.StatusNoContent},
+		{description: "get updated file", method: http.MethodGet, URL: "/folder/new.txt", expectStatus: http.StatusOK, expect: "updated"},
+		{description: "put directory", method: http.MethodPut, URL: "/folder/d", auth: true, body: "x", expectStatus: http.StatusConflict},
+		{description: "mkcol", method: MethodMkcol, URL: "/folder/sub", auth: true, expectStatus: http.StatusCreated},
+		{description: "mkcol existing", method: MethodMkcol, URL: "/folder/sub", auth: true, expectStatus: http.StatusMethodNotAllowed},
+		{description: "post folder", method: http.MethodPost, URL: "/folder/posted", auth: true, expectStatus: http.StatusCreated},
+		{description: "post existing folder", method: http.MethodPost, URL: "/folder/posted", auth: true, expectStatus: http.StatusOK},
+		{description: "post file", method: http.MethodPost, URL: "/folder/posted.txt", auth: true, body: "posted", expectStatus: http.StatusCreated},
+		{description: "delete file", method: http.MethodDelete, URL: "/folder/b.txt", auth: true, expectStatus: http.StatusNoContent},
+		{description: "delete missing", method: http.MethodDelete, URL: "/folder/b.txt", auth: true, expectStatus: http.StatusNotFound},
+		{description: "delete base", method: http.MethodDelete, URL: "/", auth: true, expectStatus: http.StatusForbidden},
+		{description: "unsupported method", method: http.MethodPatch, URL: "/folder/a.txt", auth: true, expectStatus: http.StatusMethodNotAllowed},
+	}
+	for _, useCase := range useCases {
+		request, err := http.NewRequest(useCase.method, httpServer.URL+useCase.URL, strings.NewReader(useCase.body))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		for key, values := range useCase.header {
+			request.Header[key] = values
+		}
+		if useCase.auth {
+			request.SetBasicAuth("user", "pass")
+		}
+		response, err := http.DefaultClient.Do(request)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		assert.EqualValues(t, useCase.expectStatus, response.StatusCode, useCase.description)
+		if useCase.expect != "" {
+			assert.True(t, strings.Contains(string(data), useCase.expect), useCase.description+": "+string(data))
+		}
+		if useCase.notExpect != "" {
+			assert.False(t, strings.Contains(string(data), useCase.notExpect), useCase.description+": "+string(data))
+		}
+	}
+}
+
+func TestServer_HTTPScheme(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/scheme"
+	server := NewServer(fs, baseURL)
+	server.Auth = NewBearerAuth("token")
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	source := "mem://localhost/source"
+	for URL, content := range map[string]string{
+		source + "/a.txt":     "abc",
+		source + "/sub/b.txt": "xyz",
+	} {
+		if !assert.Nil(t, fs.Upload(ctx, URL, 0644, strings.NewReader(content))) {
+			return
+		}
+	}
+	token := ahttp.NewBearer("token")
+	err := fs.Copy(ctx, source, httpServer.URL+"/copy", option.NewDest(token))
+	if !assert.Nil(t, err) {
+		return
+	}
+	objects, err := fs.List(ctx, httpServer.URL+"/copy/", token)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var names []string
+	for _, object := range objects[1:] {
+		names = append(names, object.Name())
+	}
+	sort.Strings(names)
+	assert.EqualValues(t, []string{"a.txt", "sub"}, names)
+
+	data, err := fs.DownloadWithURL(ctx, httpServer.URL+"/copy/sub/b.txt", token)
+	assert.Nil(t, err)
+	assert.EqualValues(t, "xyz", string(data))
+
+	err = fs.Delete(ctx, httpServer.URL+"/copy/a.txt", token)
+	assert.Nil(t, err)
+	exists, _ := fs.Exists(ctx, baseURL+"/copy/a.txt")
+	assert.False(t, exists)
+
+	response, err := http.Get(httpServer.URL + "/copy/sub/b.txt")
+	if assert.Nil(t, err) {
+		_ = response.Body.Close()
+		assert.EqualValues(t, http.StatusUnauthorized, response.StatusCode)
+	}
+}
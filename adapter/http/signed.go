@@ -0,0 +1,26 @@
+package http
+
+import (
+	ahttp "github.com/viant/afs/http"
+	"net/http"
+	"time"
+)
+
+type signedHandler struct {
+	handler http.Handler
+	signer  *ahttp.Signer
+}
+
+func (h *signedHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if err := h.signer.Verify(request.URL, time.Now()); err != nil {
+		http.Error(writer, err.Error(), http.StatusForbidden)
+		return
+	}
+	h.handler.ServeHTTP(writer, request)
+}
+
+// NewSignedHandler creates a handler serving only requests with valid, not expired URL signature,
+// signed URLs are produced by afs http manager with option.PreSign and the same signer
+func NewSignedHandler(handler http.Handler, signer *ahttp.Signer) http.Handler {
+	return &signedHandler{handler: handler, signer: signer}
+}
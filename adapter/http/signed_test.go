@@ -0,0 +1,73 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	ahttp "github.com/viant/afs/http"
+	"github.com/viant/afs/url"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewSignedHandler(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := url.Join("file://localhost", t.TempDir())
+	err := fs.Upload(ctx, url.Join(baseURL, "asset.txt"), 0644, strings.NewReader("test"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	signer := ahttp.NewSigner([]byte("secret"))
+	server := httptest.NewServer(NewSignedHandler(http.FileServer(New(fs, baseURL)), signer))
+	defer server.Close()
+
+	URL := server.URL + "/asset.txt"
+	signed, err := signer.Sign(URL, time.Now().Add(time.Minute))
+	if !assert.Nil(t, err) {
+		return
+	}
+	expired, err := signer.Sign(URL, time.Now().Add(-time.Minute))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		description  string
+		URL          string
+		expectStatus int
+		expect       string
+	}{
+		{
+			description:  "signed URL",
+			URL:          signed,
+			expectStatus: http.StatusOK,
+			expect:       "test",
+		},
+		{
+			description:  "expired URL",
+			URL:          expired,
+			expectStatus: http.StatusForbidden,
+		},
+		{
+			description:  "unsigned URL",
+			URL:          URL,
+			expectStatus: http.StatusForbidden,
+		},
+	}
+	for _, useCase := range useCases {
+		response, err := http.Get(useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		assert.EqualValues(t, useCase.expectStatus, response.StatusCode, useCase.description)
+		if useCase.expect != "" {
+			assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+		}
+	}
+}
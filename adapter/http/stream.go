@@ -0,0 +1,88 @@
+package http
+
+import (
+	"fmt"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//ServeStream streams non seekable content without buffering it, a single byte range is served by skipping leading content
+func ServeStream(writer http.ResponseWriter, request *http.Request, object storage.Object, reader io.Reader) error {
+	modTime := object.ModTime().UTC()
+	if since, err := http.ParseTime(request.Header.Get("If-Modified-Since")); err == nil && !modTime.Truncate(time.Second).After(since) {
+		writer.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	header := writer.Header()
+	contentType := mime.TypeByExtension(path.Ext(object.Name()))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header.Set("Content-Type", contentType)
+	header.Set("Last-Modified", modTime.Format(http.TimeFormat))
+	header.Set("Accept-Ranges", "bytes")
+	size := object.Size()
+	status := http.StatusOK
+	offset, length, ok := parseRange(request.Header.Get("Range"), size)
+	if ok {
+		status = http.StatusPartialContent
+		header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, size))
+	} else {
+		offset, length = 0, size
+	}
+	header.Set("Content-Length", strconv.FormatInt(length, 10))
+	writer.WriteHeader(status)
+	if request.Method == http.MethodHead {
+		return nil
+	}
+	if offset > 0 {
+		if _, err := io.CopyN(ioutil.Discard, reader, offset); err != nil {
+			return err
+		}
+	}
+	_, err := io.CopyN(writer, reader, length)
+	return err
+}
+
+//parseRange returns offset and length of a single satisfiable byte range
+func parseRange(value string, size int64) (int64, int64, bool) {
+	if !strings.HasPrefix(value, "bytes=") || strings.Contains(value, ",") || size <= 0 {
+		return 0, 0, false
+	}
+	bounds := strings.SplitN(strings.TrimPrefix(value, "bytes="), "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, false
+	}
+	start, end := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+	if start == "" { //suffix range
+		suffix, err := strconv.ParseInt(end, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, suffix, true
+	}
+	offset, err := strconv.ParseInt(start, 10, 64)
+	if err != nil || offset < 0 || offset >= size {
+		return 0, 0, false
+	}
+	last := size - 1
+	if end != "" {
+		if last, err = strconv.ParseInt(end, 10, 64); err != nil || last < offset {
+			return 0, 0, false
+		}
+		if last >= size {
+			last = size - 1
+		}
+	}
+	return offset, last - offset + 1, true
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	var useCases = []struct {
+		description  string
+		value        string
+		size         int64
+		expectOffset int64
+		expectLength int64
+		expectOk     bool
+	}{
+		{description: "bounded range", value: "bytes=2-3", size: 6, expectOffset: 2, expectLength: 2, expectOk: true},
+		{description: "open range", value: "bytes=4-", size: 6, expectOffset: 4, expectLength: 2, expectOk: true},
+		{description: "suffix range", value: "bytes=-2", size: 6, expectOffset: 4, expectLength: 2, expectOk: true},
+		{description: "clamped range", value: "bytes=3-100", size: 6, expectOffset: 3, expectLength: 3, expectOk: true},
+		{description: "unsatisfiable range", value: "bytes=10-", size: 6},
+		{description: "multi range", value: "bytes=0-1,3-4", size: 6},
+		{description: "no range", value: "", size: 6},
+	}
+	for _, useCase := range useCases {
+		offset, length, ok := parseRange(useCase.value, useCase.size)
+		assert.Equal(t, useCase.expectOk, ok, useCase.description)
+		if !ok {
+			continue
+		}
+		assert.Equal(t, useCase.expectOffset, offset, useCase.description)
+		assert.Equal(t, useCase.expectLength, length, useCase.description)
+	}
+}
@@ -0,0 +1,7 @@
+// Package iofs exposes afs.Service base URL as io/fs filesystem (fs.FS, fs.ReadDirFS, fs.StatFS, fs.ReadFileFS, fs.SubFS)
+//
+// Usage:
+//
+//	fsys := iofs.New(afs.New(), "gs://myBucket/templates")
+//	tmpl, err := template.ParseFS(fsys, "*.tmpl")
+package iofs
@@ -0,0 +1,139 @@
+package iofs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/fs"
+)
+
+//file represents lazily opened file, seeking or random access without native support buffers the content
+type file struct {
+	fs       *FS
+	name     string
+	object   storage.Object
+	reader   io.ReadCloser
+	buffered *bytes.Reader
+	offset   int64
+	closed   bool
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.object, nil
+}
+
+func (f *file) Read(data []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.buffered != nil {
+		return f.buffered.Read(data)
+	}
+	if f.reader == nil {
+		reader, err := f.fs.fs.Open(context.Background(), f.object, f.fs.options...)
+		if err != nil {
+			return 0, f.fs.pathError("read", f.name, f.object.URL(), err)
+		}
+		f.reader = reader
+	}
+	n, err := f.reader.Read(data)
+	f.offset += int64(n)
+	return n, err
+}
+
+//Seek seeks with buffered content
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	if err := f.buffer("seek"); err != nil {
+		return 0, err
+	}
+	return f.buffered.Seek(offset, whence)
+}
+
+//ReadAt reads at supplied offset with buffered content
+func (f *file) ReadAt(data []byte, offset int64) (int, error) {
+	if err := f.buffer("read"); err != nil {
+		return 0, err
+	}
+	return f.buffered.ReadAt(data, offset)
+}
+
+//buffer downloads the content keeping the current read offset
+func (f *file) buffer(op string) error {
+	if f.closed {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.buffered != nil {
+		return nil
+	}
+	data, err := f.fs.fs.Download(context.Background(), f.object, f.fs.options...)
+	if err != nil {
+		return f.fs.pathError(op, f.name, f.object.URL(), err)
+	}
+	if f.reader != nil {
+		_ = f.reader.Close()
+		f.reader = nil
+	}
+	f.buffered = bytes.NewReader(data)
+	_, err = f.buffered.Seek(f.offset, io.SeekStart)
+	return err
+}
+
+func (f *file) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	if f.reader != nil {
+		return f.reader.Close()
+	}
+	return nil
+}
+
+//dir represents a directory with lazily listed entries
+type dir struct {
+	fs      *FS
+	name    string
+	object  storage.Object
+	entries []fs.DirEntry
+	listed  bool
+	offset  int
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) {
+	return d.object, nil
+}
+
+func (d *dir) Read(data []byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+//ReadDir returns up to count entries, all remaining entries if count <= 0
+func (d *dir) ReadDir(count int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		entries, err := d.fs.readDir(d.name, d.object)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.listed = true
+	}
+	remaining := d.entries[d.offset:]
+	if count <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(remaining) {
+		count = len(remaining)
+	}
+	d.offset += count
+	return remaining[:count], nil
+}
+
+func (d *dir) Close() error {
+	return nil
+}
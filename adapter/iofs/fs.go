@@ -0,0 +1,139 @@
+package iofs
+
+import (
+	"context"
+	"errors"
+	"github.com/viant/afs"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io/fs"
+	"sort"
+)
+
+//FS represents afs.Service base URL backed io/fs filesystem
+type FS struct {
+	fs      afs.Service
+	baseURL string
+	options []storage.Option
+}
+
+//Open opens named file or directory
+func (f *FS) Open(name string) (fs.File, error) {
+	object, err := f.object("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if object.IsDir() {
+		return &dir{fs: f, name: name, object: object}, nil
+	}
+	return &file{fs: f, name: name, object: object}, nil
+}
+
+//Stat returns named file info
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	object, err := f.object("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+//ReadDir returns named directory entries sorted by name
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	object, err := f.object("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !object.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return f.readDir(name, object)
+}
+
+func (f *FS) readDir(name string, parent storage.Object) ([]fs.DirEntry, error) {
+	objects, err := f.fs.List(context.Background(), parent.URL(), f.options...)
+	if err != nil {
+		return nil, f.pathError("readdir", name, parent.URL(), err)
+	}
+	var result = make([]fs.DirEntry, 0, len(objects))
+	for i := range objects {
+		if objects[i].IsDir() && url.Equals(objects[i].URL(), parent.URL()) {
+			continue
+		}
+		result = append(result, fs.FileInfoToDirEntry(objects[i]))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+	return result, nil
+}
+
+//ReadFile returns named file content
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	object, err := f.object("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if object.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	data, err := f.fs.Download(context.Background(), object, f.options...)
+	if err != nil {
+		return nil, f.pathError("read", name, object.URL(), err)
+	}
+	return data, nil
+}
+
+//Sub returns filesystem rooted at named directory
+func (f *FS) Sub(dir string) (fs.FS, error) {
+	if !fs.ValidPath(dir) {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: fs.ErrInvalid}
+	}
+	if dir == "." {
+		return f, nil
+	}
+	object, err := f.object("sub", dir)
+	if err != nil {
+		return nil, err
+	}
+	if !object.IsDir() {
+		return nil, &fs.PathError{Op: "sub", Path: dir, Err: errors.New("not a directory")}
+	}
+	return &FS{fs: f.fs, baseURL: f.URL(dir), options: f.options}, nil
+}
+
+//URL returns storage URL for supplied name
+func (f *FS) URL(name string) string {
+	if name == "." {
+		return f.baseURL
+	}
+	return url.Join(f.baseURL, name)
+}
+
+func (f *FS) object(op, name string) (storage.Object, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	URL := f.URL(name)
+	object, err := f.fs.Object(context.Background(), URL, f.options...)
+	if err != nil {
+		return nil, f.pathError(op, name, URL, err)
+	}
+	return object, nil
+}
+
+//pathError maps storage error to path error, not existing resource is reported with fs.ErrNotExist
+func (f *FS) pathError(op, name, URL string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if exists, e := f.fs.Exists(context.Background(), URL, f.options...); e == nil && !exists {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+//New creates io/fs filesystem for supplied service and base URL
+func New(service afs.Service, baseURL string, options ...storage.Option) *FS {
+	return &FS{fs: service, baseURL: url.Normalize(baseURL, "file"), options: options}
+}
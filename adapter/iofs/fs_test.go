@@ -0,0 +1,74 @@
+package iofs
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFS(t *testing.T) {
+	assets := map[string]string{
+		"asset1.txt":          "test 1",
+		"folder/asset2.txt":   "test 2",
+		"folder/sub/asset3.x": "test 3",
+	}
+	var useCases = []struct {
+		description string
+		baseURL     string
+	}{
+		{
+			description: "memory storage",
+			baseURL:     "mem://localhost/iofs",
+		},
+		{
+			description: "file storage",
+			baseURL:     url.Join("file://localhost", t.TempDir()),
+		},
+	}
+
+	ctx := context.Background()
+	service := afs.New()
+	for _, useCase := range useCases {
+		var expected []string
+		for name, content := range assets {
+			expected = append(expected, name)
+			err := service.Upload(ctx, url.Join(useCase.baseURL, name), 0644, strings.NewReader(content))
+			if !assert.Nil(t, err, useCase.description) {
+				return
+			}
+		}
+		fsys := New(service, useCase.baseURL)
+		assert.Nil(t, fstest.TestFS(fsys, expected...), useCase.description)
+
+		data, err := fs.ReadFile(fsys, "folder/asset2.txt")
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, "test 2", string(data), useCase.description)
+
+		_, err = fsys.Open("missing.txt")
+		assert.True(t, errors.Is(err, fs.ErrNotExist), useCase.description)
+		_, err = fsys.Stat("folder/missing.txt")
+		assert.True(t, errors.Is(err, fs.ErrNotExist), useCase.description)
+		_, err = fsys.Open("../asset1.txt")
+		assert.True(t, errors.Is(err, fs.ErrInvalid), useCase.description)
+
+		sub, err := fs.Sub(fsys, "folder")
+		if assert.Nil(t, err, useCase.description) {
+			assert.Nil(t, fstest.TestFS(sub, "asset2.txt", "sub/asset3.x"), useCase.description)
+		}
+		var walked []string
+		err = fs.WalkDir(fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() {
+				walked = append(walked, path)
+			}
+			return err
+		})
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, []string{"asset1.txt", "folder/asset2.txt", "folder/sub/asset3.x"}, walked, useCase.description)
+	}
+}
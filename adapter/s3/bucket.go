@@ -0,0 +1,90 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+type bucketInfo struct {
+	Name         string `xml:"Name"`
+	CreationDate string `xml:"CreationDate"`
+}
+
+type listAllMyBucketsResult struct {
+	XMLName xml.Name      `xml:"ListAllMyBucketsResult"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Owner   owner         `xml:"Owner"`
+	Buckets []*bucketInfo `xml:"Buckets>Bucket"`
+}
+
+type owner struct {
+	ID          string `xml:"ID"`
+	DisplayName string `xml:"DisplayName"`
+}
+
+type locationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+//isValidBucket returns true for non hidden single segment bucket name
+func isValidBucket(bucket string) bool {
+	return bucket != "" && !strings.HasPrefix(bucket, ".") && !strings.Contains(bucket, "/")
+}
+
+func (s *Server) listBuckets(ctx context.Context, writer http.ResponseWriter) error {
+	objects, err := s.fs.List(ctx, s.baseURL, s.options...)
+	if err != nil {
+		return err
+	}
+	result := &listAllMyBucketsResult{Xmlns: xmlNamespace, Owner: owner{ID: "afs", DisplayName: "afs"}, Buckets: []*bucketInfo{}}
+	for _, object := range objects {
+		if !object.IsDir() || url.Equals(object.URL(), s.baseURL) || !isValidBucket(object.Name()) {
+			continue
+		}
+		result.Buckets = append(result.Buckets, &bucketInfo{Name: object.Name(), CreationDate: object.ModTime().UTC().Format(time.RFC3339)})
+	}
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Name < result.Buckets[j].Name
+	})
+	return writeXML(writer, http.StatusOK, result)
+}
+
+func (s *Server) createBucket(ctx context.Context, writer http.ResponseWriter, bucket string) error {
+	if !isValidBucket(bucket) {
+		return newError(http.StatusBadRequest, "InvalidBucketName", "the specified bucket is not valid: "+bucket)
+	}
+	if s.checkBucket(ctx, bucket) == nil {
+		return newError(http.StatusConflict, "BucketAlreadyOwnedByYou", "bucket already exists: "+bucket)
+	}
+	if err := s.fs.Create(ctx, s.bucketURL(bucket), file.DefaultDirOsMode, true, s.options...); err != nil {
+		return err
+	}
+	writer.Header().Set("Location", "/"+bucket)
+	writer.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (s *Server) deleteBucket(ctx context.Context, writer http.ResponseWriter, bucket string) error {
+	if err := s.checkBucket(ctx, bucket); err != nil {
+		return err
+	}
+	objects, err := s.fs.List(ctx, s.bucketURL(bucket), s.options...)
+	if err != nil {
+		return err
+	}
+	if len(objects) > 1 {
+		return newError(http.StatusConflict, "BucketNotEmpty", "the bucket you tried to delete is not empty: "+bucket)
+	}
+	if err = s.fs.Delete(ctx, s.bucketURL(bucket), s.options...); err != nil {
+		return err
+	}
+	writer.WriteHeader(http.StatusNoContent)
+	return nil
+}
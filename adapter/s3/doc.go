@@ -0,0 +1,11 @@
+// Package s3 exposes afs.Service base URL with minimal S3 compatible REST API (path style requests):
+// ListBuckets, CreateBucket, DeleteBucket, HeadBucket, ListObjectsV2, GetObject (with ranges), HeadObject,
+// PutObject, DeleteObject, DeleteObjects and multipart upload.
+//
+// Buckets are base URL folders, object keys are paths within a bucket folder.
+//
+// Usage:
+//
+//	server := httptest.NewServer(s3.New(afs.New(), "mem://localhost/s3"))
+//	//point S3 client endpoint to server.URL with path style addressing
+package s3
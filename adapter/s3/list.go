@@ -0,0 +1,227 @@
+package s3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMaxKeys = 1000
+
+type objectInfo struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name        `xml:"ListBucketResult"`
+	Xmlns                 string          `xml:"xmlns,attr"`
+	Name                  string          `xml:"Name"`
+	Prefix                string          `xml:"Prefix"`
+	Delimiter             string          `xml:"Delimiter,omitempty"`
+	StartAfter            string          `xml:"StartAfter,omitempty"`
+	ContinuationToken     string          `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string          `xml:"NextContinuationToken,omitempty"`
+	KeyCount              int             `xml:"KeyCount"`
+	MaxKeys               int             `xml:"MaxKeys"`
+	IsTruncated           bool            `xml:"IsTruncated"`
+	Contents              []*objectInfo   `xml:"Contents"`
+	CommonPrefixes        []*commonPrefix `xml:"CommonPrefixes"`
+}
+
+//listItem represents folder entry with its object key, folder keys end with slash
+type listItem struct {
+	key    string
+	object storage.Object
+}
+
+//lister walks bucket folders in key order starting from the marker, walking stops once max keys are listed
+type lister struct {
+	*Server
+	bucket    string
+	prefix    string
+	delimiter string
+	marker    string
+	last      string
+	result    *listBucketResult
+}
+
+//listObjects lists bucket keys with ListObjectsV2 semantics
+func (s *Server) listObjects(ctx context.Context, writer http.ResponseWriter, bucket string, query neturl.Values) error {
+	result := &listBucketResult{
+		Xmlns:             xmlNamespace,
+		Name:              bucket,
+		Prefix:            query.Get("prefix"),
+		Delimiter:         query.Get("delimiter"),
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		MaxKeys:           defaultMaxKeys,
+	}
+	if value := query.Get("max-keys"); value != "" {
+		maxKeys, err := strconv.Atoi(value)
+		if err != nil || maxKeys < 0 {
+			return newError(http.StatusBadRequest, "InvalidArgument", "invalid max-keys: "+value)
+		}
+		result.MaxKeys = maxKeys
+	}
+	marker := result.StartAfter
+	if result.ContinuationToken != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(result.ContinuationToken)
+		if err != nil {
+			return newError(http.StatusBadRequest, "InvalidArgument", "invalid continuation token")
+		}
+		marker = string(decoded)
+	}
+	lister := &lister{Server: s, bucket: bucket, prefix: result.Prefix, delimiter: result.Delimiter, marker: marker, result: result}
+	if _, err := lister.visit(ctx, ""); err != nil {
+		return err
+	}
+	if result.IsTruncated {
+		result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(lister.last))
+	}
+	return writeXML(writer, http.StatusOK, result)
+}
+
+//visit lists folder entries in key order, it returns false once listing is truncated
+func (l *lister) visit(ctx context.Context, dir string) (bool, error) {
+	URL := l.bucketURL(l.bucket)
+	if dir != "" {
+		URL = l.objectURL(l.bucket, dir)
+	}
+	objects, err := l.fs.List(ctx, URL, l.options...)
+	if err != nil {
+		return false, err
+	}
+	var items = make([]*listItem, 0, len(objects))
+	for _, object := range objects {
+		if object.IsDir() && url.Equals(object.URL(), URL) {
+			continue
+		}
+		key := dir + object.Name()
+		if object.IsDir() {
+			key += "/"
+		}
+		items = append(items, &listItem{key: key, object: object})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].key < items[j].key
+	})
+	for _, item := range items {
+		if !item.object.IsDir() {
+			if !strings.HasPrefix(item.key, l.prefix) {
+				continue
+			}
+			if next, err := l.add(ctx, item.key, item.object); err != nil || !next {
+				return next, err
+			}
+			continue
+		}
+		if item.key <= l.marker && !strings.HasPrefix(l.marker, item.key) { //all folder keys precede the marker
+			continue
+		}
+		if !strings.HasPrefix(item.key, l.prefix) && !strings.HasPrefix(l.prefix, item.key) {
+			continue
+		}
+		if prefix := l.commonPrefix(item.key); prefix != "" {
+			if prefix <= l.marker || prefix == l.last {
+				continue
+			}
+			hasFiles, err := l.hasFiles(ctx, item.object.URL())
+			if err != nil {
+				return false, err
+			}
+			if !hasFiles {
+				continue
+			}
+			if next, err := l.add(ctx, prefix, nil); err != nil || !next {
+				return next, err
+			}
+			continue
+		}
+		if next, err := l.visit(ctx, item.key); err != nil || !next {
+			return next, err
+		}
+	}
+	return true, nil
+}
+
+//commonPrefix returns key up to the first delimiter past the listing prefix or empty string
+func (l *lister) commonPrefix(key string) string {
+	if l.delimiter == "" || !strings.HasPrefix(key, l.prefix) {
+		return ""
+	}
+	if index := strings.Index(key[len(l.prefix):], l.delimiter); index != -1 {
+		return key[:len(l.prefix)+index+len(l.delimiter)]
+	}
+	return ""
+}
+
+//add appends an object or a common prefix (nil object), it returns false once listing is truncated
+func (l *lister) add(ctx context.Context, key string, object storage.Object) (bool, error) {
+	if prefix := l.commonPrefix(key); prefix != "" {
+		key, object = prefix, nil
+	}
+	if key <= l.marker || key == l.last {
+		return true, nil
+	}
+	result := l.result
+	if result.KeyCount == result.MaxKeys {
+		result.IsTruncated = true
+		return false, nil
+	}
+	if object == nil {
+		result.CommonPrefixes = append(result.CommonPrefixes, &commonPrefix{Prefix: key})
+	} else {
+		etag, err := l.objectETag(ctx, object)
+		if err != nil {
+			return false, err
+		}
+		result.Contents = append(result.Contents, &objectInfo{
+			Key:          key,
+			LastModified: object.ModTime().UTC().Format(time.RFC3339),
+			ETag:         etag,
+			Size:         object.Size(),
+			StorageClass: "STANDARD",
+		})
+	}
+	result.KeyCount++
+	l.last = key
+	return true, nil
+}
+
+//hasFiles returns true if folder or any of its sub folder contains a file
+func (s *Server) hasFiles(ctx context.Context, URL string) (bool, error) {
+	objects, err := s.fs.List(ctx, URL, s.options...)
+	if err != nil {
+		return false, err
+	}
+	for _, object := range objects {
+		if !object.IsDir() {
+			return true, nil
+		}
+	}
+	for _, object := range objects {
+		if url.Equals(object.URL(), URL) {
+			continue
+		}
+		if hasFiles, err := s.hasFiles(ctx, object.URL()); err != nil || hasFiles {
+			return hasFiles, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,168 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_ListObjects(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/s3list"
+	for _, key := range []string{"a.txt", "b/c.txt", "b/d/e.txt", "b/f.txt", "g/h.txt", "i-j.txt", "i-k.txt"} {
+		if !assert.Nil(t, fs.Upload(ctx, baseURL+"/bucket/"+key, 0644, strings.NewReader(key))) {
+			return
+		}
+	}
+	if !assert.Nil(t, fs.Create(ctx, baseURL+"/bucket/empty", 0755, true)) {
+		return
+	}
+	server := httptest.NewServer(New(fs, baseURL))
+	defer server.Close()
+
+	var useCases = []struct {
+		description    string
+		query          string
+		expectKeys     []string
+		expectPrefixes []string
+		expectTrunc    bool
+	}{
+		{
+			description: "all keys",
+			query:       "list-type=2",
+			expectKeys:  []string{"a.txt", "b/c.txt", "b/d/e.txt", "b/f.txt", "g/h.txt", "i-j.txt", "i-k.txt"},
+		},
+		{
+			description:    "root delimiter",
+			query:          "list-type=2&delimiter=/",
+			expectKeys:     []string{"a.txt", "i-j.txt", "i-k.txt"},
+			expectPrefixes: []string{"b/", "g/"},
+		},
+		{
+			description:    "prefix with delimiter",
+			query:          "list-type=2&prefix=b/&delimiter=/",
+			expectKeys:     []string{"b/c.txt", "b/f.txt"},
+			expectPrefixes: []string{"b/d/"},
+		},
+		{
+			description: "partial prefix",
+			query:       "list-type=2&prefix=b/d",
+			expectKeys:  []string{"b/d/e.txt"},
+		},
+		{
+			description:    "custom delimiter",
+			query:          "list-type=2&delimiter=-",
+			expectKeys:     []string{"a.txt", "b/c.txt", "b/d/e.txt", "b/f.txt", "g/h.txt"},
+			expectPrefixes: []string{"i-"},
+		},
+		{
+			description: "start after",
+			query:       "list-type=2&start-after=b/f.txt",
+			expectKeys:  []string{"g/h.txt", "i-j.txt", "i-k.txt"},
+		},
+		{
+			description:    "max keys",
+			query:          "list-type=2&delimiter=/&max-keys=2",
+			expectKeys:     []string{"a.txt"},
+			expectPrefixes: []string{"b/"},
+			expectTrunc:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		result, ok := listBucket(t, server.URL+"/bucket?"+useCase.query)
+		if !ok {
+			continue
+		}
+		assert.EqualValues(t, useCase.expectKeys, keys(result), useCase.description)
+		assert.EqualValues(t, useCase.expectPrefixes, prefixes(result), useCase.description)
+		assert.EqualValues(t, useCase.expectTrunc, result.IsTruncated, useCase.description)
+		assert.EqualValues(t, len(useCase.expectKeys)+len(useCase.expectPrefixes), result.KeyCount, useCase.description)
+	}
+
+	result, ok := listBucket(t, server.URL+"/bucket?list-type=2&max-keys=1")
+	if ok && assert.EqualValues(t, 1, len(result.Contents)) {
+		assert.EqualValues(t, `"a5e54d1fd7bb69a228ef0dcd2431367e"`, result.Contents[0].ETag)
+	}
+
+	//continuation token pagination
+	var paginationCases = []struct {
+		description    string
+		query          string
+		expectKeys     []string
+		expectPrefixes []string
+	}{
+		{
+			description: "all keys",
+			query:       "list-type=2&max-keys=3",
+			expectKeys:  []string{"a.txt", "b/c.txt", "b/d/e.txt", "b/f.txt", "g/h.txt", "i-j.txt", "i-k.txt"},
+		},
+		{
+			description:    "root delimiter",
+			query:          "list-type=2&delimiter=/&max-keys=1",
+			expectKeys:     []string{"a.txt", "i-j.txt", "i-k.txt"},
+			expectPrefixes: []string{"b/", "g/"},
+		},
+		{
+			description:    "prefix with delimiter",
+			query:          "list-type=2&prefix=b/&delimiter=/&max-keys=2",
+			expectKeys:     []string{"b/c.txt", "b/f.txt"},
+			expectPrefixes: []string{"b/d/"},
+		},
+	}
+	for _, useCase := range paginationCases {
+		var actualKeys, actualPrefixes []string
+		token := ""
+		for i := 0; i < 10; i++ {
+			URL := server.URL + "/bucket?" + useCase.query
+			if token != "" {
+				URL += "&continuation-token=" + token
+			}
+			result, ok := listBucket(t, URL)
+			if !ok {
+				break
+			}
+			actualKeys = append(actualKeys, keys(result)...)
+			actualPrefixes = append(actualPrefixes, prefixes(result)...)
+			if !result.IsTruncated {
+				break
+			}
+			token = result.NextContinuationToken
+		}
+		assert.EqualValues(t, useCase.expectKeys, actualKeys, useCase.description)
+		assert.EqualValues(t, useCase.expectPrefixes, actualPrefixes, useCase.description)
+	}
+}
+
+func listBucket(t *testing.T, URL string) (*listBucketResult, bool) {
+	status, _, body := send(t, http.MethodGet, URL, nil, nil)
+	if !assert.EqualValues(t, http.StatusOK, status, body) {
+		return nil, false
+	}
+	result := &listBucketResult{}
+	if !assert.Nil(t, xml.Unmarshal([]byte(body), result)) {
+		return nil, false
+	}
+	return result, true
+}
+
+func keys(result *listBucketResult) []string {
+	var keys []string
+	for _, content := range result.Contents {
+		keys = append(keys, content.Key)
+	}
+	return keys
+}
+
+func prefixes(result *listBucketResult) []string {
+	var prefixes []string
+	for _, prefix := range result.CommonPrefixes {
+		prefixes = append(prefixes, prefix.Prefix)
+	}
+	return prefixes
+}
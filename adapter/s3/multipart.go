@@ -0,0 +1,182 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	//uploadsFolder represents base URL folder with pending multipart uploads, it is not a valid bucket name
+	uploadsFolder = ".multipart"
+	uploadKeyFile = "key"
+	partTagExt    = ".md5"
+	maxPartNumber = 10000
+)
+
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+	Parts   []*completedPart `xml:"Part"`
+}
+
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+func (s *Server) uploadURL(uploadID string) string {
+	return url.Join(s.baseURL, uploadsFolder, uploadID)
+}
+
+func partName(partNumber int) string {
+	return fmt.Sprintf("%05d", partNumber)
+}
+
+func (s *Server) createUpload(ctx context.Context, writer http.ResponseWriter, bucket, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	uploadID := hex.EncodeToString(id)
+	keyURL := url.Join(s.uploadURL(uploadID), uploadKeyFile)
+	if err := s.fs.Upload(ctx, keyURL, file.DefaultFileOsMode, strings.NewReader(bucket+"/"+key), s.options...); err != nil {
+		return err
+	}
+	return writeXML(writer, http.StatusOK, &initiateMultipartUploadResult{Xmlns: xmlNamespace, Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+//checkUpload returns NoSuchUpload error if upload does not exist or was created for other object
+func (s *Server) checkUpload(ctx context.Context, bucket, key, uploadID string) error {
+	notFound := newError(http.StatusNotFound, "NoSuchUpload", "the specified upload does not exist: "+uploadID)
+	if strings.ContainsAny(uploadID, "/.") {
+		return notFound
+	}
+	data, err := s.fs.DownloadWithURL(ctx, url.Join(s.uploadURL(uploadID), uploadKeyFile), s.options...)
+	if err != nil || string(data) != bucket+"/"+key {
+		return notFound
+	}
+	return nil
+}
+
+func (s *Server) uploadPart(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket, key, uploadID, partNumber string) error {
+	if err := s.checkUpload(ctx, bucket, key, uploadID); err != nil {
+		return err
+	}
+	number, err := strconv.Atoi(partNumber)
+	if err != nil || number < 1 || number > maxPartNumber {
+		return newError(http.StatusBadRequest, "InvalidArgument", "invalid part number: "+partNumber)
+	}
+	hash := md5.New()
+	reader := io.TeeReader(request.Body, hash)
+	partURL := url.Join(s.uploadURL(uploadID), partName(number))
+	if err = s.fs.Upload(ctx, partURL, file.DefaultFileOsMode, reader, s.options...); err != nil {
+		return err
+	}
+	tag := hex.EncodeToString(hash.Sum(nil))
+	if err = s.fs.Upload(ctx, partURL+partTagExt, file.DefaultFileOsMode, strings.NewReader(tag), s.options...); err != nil {
+		return err
+	}
+	writer.Header().Set("ETag", `"`+tag+`"`)
+	writer.WriteHeader(http.StatusOK)
+	return nil
+}
+
+//completeUpload concatenates listed parts into the destination object and removes the upload, listed part entity tags have to match uploaded parts
+func (s *Server) completeUpload(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket, key, uploadID string) error {
+	if err := s.checkUpload(ctx, bucket, key, uploadID); err != nil {
+		return err
+	}
+	complete := &completeMultipartUpload{}
+	if err := xml.NewDecoder(request.Body).Decode(complete); err != nil || len(complete.Parts) == 0 {
+		return newError(http.StatusBadRequest, "MalformedXML", "invalid complete multipart upload request")
+	}
+	var readers = make([]io.Reader, 0, len(complete.Parts))
+	var closers = make([]io.Closer, 0, len(complete.Parts))
+	defer func() {
+		for _, closer := range closers {
+			_ = closer.Close()
+		}
+	}()
+	hash := md5.New()
+	for i, part := range complete.Parts {
+		if i > 0 && part.PartNumber <= complete.Parts[i-1].PartNumber {
+			return newError(http.StatusBadRequest, "InvalidPartOrder", "the list of parts was not in ascending order")
+		}
+		partURL := url.Join(s.uploadURL(uploadID), partName(part.PartNumber))
+		tag, err := s.fs.DownloadWithURL(ctx, partURL+partTagExt, s.options...)
+		if err != nil {
+			return newError(http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part %v could not be found", part.PartNumber))
+		}
+		digest, err := hex.DecodeString(string(tag))
+		if err != nil || strings.Trim(part.ETag, `"`) != string(tag) {
+			return newError(http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part %v entity tag does not match", part.PartNumber))
+		}
+		hash.Write(digest)
+		reader, err := s.fs.OpenURL(ctx, partURL, s.options...)
+		if err != nil {
+			return newError(http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part %v could not be found", part.PartNumber))
+		}
+		closers = append(closers, reader)
+		readers = append(readers, reader)
+	}
+	URL := s.objectURL(bucket, key)
+	if err := s.fs.Upload(ctx, URL, file.DefaultFileOsMode, io.MultiReader(readers...), s.options...); err != nil {
+		return err
+	}
+	object, err := s.fs.Object(ctx, URL, s.options...)
+	if err != nil {
+		return err
+	}
+	if err = s.fs.Delete(ctx, s.uploadURL(uploadID), s.options...); err != nil {
+		return err
+	}
+	etag := fmt.Sprintf(`"%x-%d"`, hash.Sum(nil), len(complete.Parts))
+	s.setETag(object, etag)
+	return writeXML(writer, http.StatusOK, &completeMultipartUploadResult{
+		Xmlns:    xmlNamespace,
+		Location: "/" + bucket + "/" + key,
+		Bucket:   bucket,
+		Key:      key,
+		ETag:     etag,
+	})
+}
+
+func (s *Server) abortUpload(ctx context.Context, writer http.ResponseWriter, bucket, key, uploadID string) error {
+	if err := s.checkUpload(ctx, bucket, key, uploadID); err != nil {
+		return err
+	}
+	if err := s.fs.Delete(ctx, s.uploadURL(uploadID), s.options...); err != nil {
+		return err
+	}
+	writer.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,127 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/xml"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_MultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/s3multipart"
+	if !assert.Nil(t, fs.Create(ctx, baseURL+"/bucket", 0755, true)) {
+		return
+	}
+	server := httptest.NewServer(New(fs, baseURL))
+	defer server.Close()
+	objectURL := server.URL + "/bucket/dir/asset.bin"
+
+	var useCases = []struct {
+		description  string
+		parts        map[int]string
+		complete     []int
+		abort        bool
+		invalidETag  bool
+		expectStatus int
+		expect       string
+	}{
+		{
+			description:  "complete upload",
+			parts:        map[int]string{1: "part1-", 2: "part2-", 3: "part3"},
+			complete:     []int{1, 2, 3},
+			expectStatus: http.StatusOK,
+			expect:       "part1-part2-part3",
+		},
+		{
+			description:  "complete with subset of parts",
+			parts:        map[int]string{1: "abc", 2: "def", 3: "xyz"},
+			complete:     []int{1, 3},
+			expectStatus: http.StatusOK,
+			expect:       "abcxyz",
+		},
+		{
+			description:  "missing part",
+			parts:        map[int]string{1: "abc"},
+			complete:     []int{1, 2},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			description:  "invalid part order",
+			parts:        map[int]string{1: "abc", 2: "def"},
+			complete:     []int{2, 1},
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			description:  "part entity tag mismatch",
+			parts:        map[int]string{1: "abc", 2: "def"},
+			complete:     []int{1, 2},
+			invalidETag:  true,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			description:  "abort upload",
+			parts:        map[int]string{1: "abc"},
+			abort:        true,
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, useCase := range useCases {
+		status, _, body := send(t, http.MethodPost, objectURL+"?uploads", nil, nil)
+		if !assert.EqualValues(t, http.StatusOK, status, useCase.description) {
+			continue
+		}
+		initiated := &initiateMultipartUploadResult{}
+		if !assert.Nil(t, xml.Unmarshal([]byte(body), initiated), useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, "dir/asset.bin", initiated.Key, useCase.description)
+		etags := map[int]string{}
+		for number, content := range useCase.parts {
+			status, header, _ := send(t, http.MethodPut, fmt.Sprintf("%v?partNumber=%v&uploadId=%v", objectURL, number, initiated.UploadID), strings.NewReader(content), nil)
+			assert.EqualValues(t, http.StatusOK, status, useCase.description)
+			assert.EqualValues(t, fmt.Sprintf(`"%x"`, md5.Sum([]byte(content))), header.Get("ETag"), useCase.description)
+			etags[number] = header.Get("ETag")
+			if useCase.invalidETag {
+				etags[number] = fmt.Sprintf(`"%x"`, md5.Sum([]byte(content+"-")))
+			}
+		}
+		if useCase.abort {
+			status, _, _ = send(t, http.MethodDelete, objectURL+"?uploadId="+initiated.UploadID, nil, nil)
+			assert.EqualValues(t, http.StatusNoContent, status, useCase.description)
+		}
+		complete := &completeMultipartUpload{}
+		for _, number := range useCase.complete {
+			complete.Parts = append(complete.Parts, &completedPart{PartNumber: number, ETag: etags[number]})
+		}
+		if len(complete.Parts) == 0 {
+			complete.Parts = append(complete.Parts, &completedPart{PartNumber: 1})
+		}
+		payload, _ := xml.Marshal(complete)
+		status, _, body = send(t, http.MethodPost, objectURL+"?uploadId="+initiated.UploadID, strings.NewReader(string(payload)), nil)
+		assert.EqualValues(t, useCase.expectStatus, status, useCase.description+": "+body)
+		if useCase.expect == "" {
+			continue
+		}
+		completed := &completeMultipartUploadResult{}
+		assert.Nil(t, xml.Unmarshal([]byte(body), completed), useCase.description)
+		assert.True(t, strings.HasSuffix(completed.ETag, fmt.Sprintf(`-%d"`, len(useCase.complete))), useCase.description)
+		_, header, body := send(t, http.MethodGet, objectURL, nil, nil)
+		assert.EqualValues(t, useCase.expect, body, useCase.description)
+		assert.EqualValues(t, completed.ETag, header.Get("ETag"), useCase.description)
+		exists, _ := fs.Exists(ctx, baseURL+"/"+uploadsFolder+"/"+initiated.UploadID)
+		assert.False(t, exists, useCase.description)
+	}
+
+	status, _, body := send(t, http.MethodPut, objectURL+"?partNumber=1&uploadId=unknown", strings.NewReader("abc"), nil)
+	assert.EqualValues(t, http.StatusNotFound, status)
+	assert.True(t, strings.Contains(body, "NoSuchUpload"), body)
+}
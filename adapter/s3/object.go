@@ -0,0 +1,183 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	ahttp "github.com/viant/afs/adapter/http"
+	"github.com/viant/afs/file"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type objectIdentifier struct {
+	Key string `xml:"Key"`
+}
+
+type deleteRequest struct {
+	XMLName xml.Name            `xml:"Delete"`
+	Quiet   bool                `xml:"Quiet"`
+	Objects []*objectIdentifier `xml:"Object"`
+}
+
+type deleteError struct {
+	Key     string `xml:"Key"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+type deleteResult struct {
+	XMLName xml.Name            `xml:"DeleteResult"`
+	Xmlns   string              `xml:"xmlns,attr"`
+	Deleted []*objectIdentifier `xml:"Deleted"`
+	Errors  []*deleteError      `xml:"Error"`
+}
+
+//checkKey returns an error for keys escaping bucket folder
+func checkKey(key string) error {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." {
+			return newError(http.StatusBadRequest, "InvalidArgument", "unsupported object key: "+key)
+		}
+	}
+	return nil
+}
+
+func (s *Server) getObject(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	object, err := s.fs.Object(ctx, s.objectURL(bucket, key), s.options...)
+	if err != nil || object.IsDir() {
+		return newError(http.StatusNotFound, "NoSuchKey", "the specified key does not exist: "+key)
+	}
+	reader, err := s.fs.Open(ctx, object, s.options...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	etag, cached := s.cachedETag(object)
+	if content, ok := reader.(io.ReadSeeker); ok {
+		if !cached {
+			if etag, err = contentETag(content); err != nil {
+				return err
+			}
+			s.setETag(object, etag)
+		}
+		writer.Header().Set("ETag", etag)
+		http.ServeContent(writer, request, object.Name(), object.ModTime(), content)
+		return nil
+	}
+	if !cached && request.Method == http.MethodHead {
+		if etag, err = s.objectETag(ctx, object); err != nil {
+			return err
+		}
+		cached = true
+	}
+	if cached {
+		writer.Header().Set("ETag", etag)
+	}
+	//uncached entity tag is computed from streamed content and served by subsequent requests
+	hash := &sizeHash{Hash: md5.New()}
+	if err = ahttp.ServeStream(writer, request, object, io.TeeReader(reader, hash)); err != nil {
+		return err
+	}
+	if !cached && hash.size == object.Size() {
+		s.setETag(object, `"`+hex.EncodeToString(hash.Sum(nil))+`"`)
+	}
+	return nil
+}
+
+//contentETag returns quoted content MD5, content is rewound after hashing
+func contentETag(content io.ReadSeeker) (string, error) {
+	hash := md5.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`, nil
+}
+
+//sizeHash represents hash tracking number of hashed bytes
+type sizeHash struct {
+	hash.Hash
+	size int64
+}
+
+func (h *sizeHash) Write(p []byte) (int, error) {
+	h.size += int64(len(p))
+	return h.Hash.Write(p)
+}
+
+//putObject uploads request body, key with trailing slash and no content creates a folder
+func (s *Server) putObject(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket, key string) error {
+	if err := checkKey(strings.TrimSuffix(key, "/")); err != nil {
+		return err
+	}
+	URL := s.objectURL(bucket, key)
+	if strings.HasSuffix(key, "/") && request.ContentLength <= 0 {
+		if err := s.fs.Create(ctx, URL, file.DefaultDirOsMode, true, s.options...); err != nil {
+			return err
+		}
+		writer.WriteHeader(http.StatusOK)
+		return nil
+	}
+	hash := md5.New()
+	if err := s.fs.Upload(ctx, URL, file.DefaultFileOsMode, io.TeeReader(request.Body, hash), s.options...); err != nil {
+		return err
+	}
+	object, err := s.fs.Object(ctx, URL, s.options...)
+	if err != nil {
+		return err
+	}
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)) + `"`
+	s.setETag(object, etag)
+	writer.Header().Set("ETag", etag)
+	writer.WriteHeader(http.StatusOK)
+	return nil
+}
+
+//deleteObject removes an object, deleting missing object succeeds
+func (s *Server) deleteObject(ctx context.Context, writer http.ResponseWriter, bucket, key string) error {
+	if err := s.delete(ctx, bucket, key); err != nil {
+		return err
+	}
+	writer.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *Server) delete(ctx context.Context, bucket, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	URL := s.objectURL(bucket, key)
+	object, err := s.fs.Object(ctx, URL, s.options...)
+	if err != nil || object.IsDir() {
+		return nil
+	}
+	s.etags.Delete(object.URL())
+	return s.fs.Delete(ctx, URL, s.options...)
+}
+
+func (s *Server) deleteObjects(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket string) error {
+	deleteRequest := &deleteRequest{}
+	if err := xml.NewDecoder(request.Body).Decode(deleteRequest); err != nil {
+		return newError(http.StatusBadRequest, "MalformedXML", err.Error())
+	}
+	result := &deleteResult{Xmlns: xmlNamespace}
+	for _, object := range deleteRequest.Objects {
+		if err := s.delete(ctx, bucket, object.Key); err != nil {
+			result.Errors = append(result.Errors, &deleteError{Key: object.Key, Code: "InternalError", Message: err.Error()})
+			continue
+		}
+		if !deleteRequest.Quiet {
+			result.Deleted = append(result.Deleted, object)
+		}
+	}
+	return writeXML(writer, http.StatusOK, result)
+}
@@ -0,0 +1,229 @@
+package s3
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/viant/afs"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	xmlNamespace   = "http://s3.amazonaws.com/doc/2006-03-01/"
+	xmlContentType = "application/xml"
+)
+
+//Server represents S3 compatible handler backed by afs.Service base URL
+type Server struct {
+	fs      afs.Service
+	baseURL string
+	options []storage.Option
+	etags   sync.Map
+}
+
+//entityTag represents object entity tag computed for object size and modification time
+type entityTag struct {
+	size    int64
+	modTime time.Time
+	value   string
+}
+
+//Error represents S3 error response
+type Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string   `xml:"Code"`
+	Message  string   `xml:"Message"`
+	Resource string   `xml:"Resource,omitempty"`
+	status   int
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+func newError(status int, code, message string) *Error {
+	return &Error{Code: code, Message: message, status: status}
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	bucket, key := bucketKey(request.URL.Path)
+	ctx := request.Context()
+	var err error
+	switch {
+	case bucket == "":
+		err = s.serviceRequest(ctx, writer, request)
+	case key == "":
+		err = s.bucketRequest(ctx, writer, request, bucket)
+	default:
+		err = s.objectRequest(ctx, writer, request, bucket, key)
+	}
+	if err != nil {
+		s.writeError(writer, request, err)
+	}
+}
+
+func (s *Server) serviceRequest(ctx context.Context, writer http.ResponseWriter, request *http.Request) error {
+	if request.Method != http.MethodGet {
+		return newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported method: "+request.Method)
+	}
+	return s.listBuckets(ctx, writer)
+}
+
+func (s *Server) bucketRequest(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket string) error {
+	query := request.URL.Query()
+	switch request.Method {
+	case http.MethodGet:
+		if err := s.checkBucket(ctx, bucket); err != nil {
+			return err
+		}
+		if _, ok := query["location"]; ok {
+			return writeXML(writer, http.StatusOK, &locationConstraint{Xmlns: xmlNamespace})
+		}
+		return s.listObjects(ctx, writer, bucket, query)
+	case http.MethodHead:
+		if err := s.checkBucket(ctx, bucket); err != nil {
+			return err
+		}
+		writer.WriteHeader(http.StatusOK)
+		return nil
+	case http.MethodPut:
+		return s.createBucket(ctx, writer, bucket)
+	case http.MethodDelete:
+		return s.deleteBucket(ctx, writer, bucket)
+	case http.MethodPost:
+		if _, ok := query["delete"]; ok {
+			if err := s.checkBucket(ctx, bucket); err != nil {
+				return err
+			}
+			return s.deleteObjects(ctx, writer, request, bucket)
+		}
+	}
+	return newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported bucket method: "+request.Method)
+}
+
+func (s *Server) objectRequest(ctx context.Context, writer http.ResponseWriter, request *http.Request, bucket, key string) error {
+	if err := s.checkBucket(ctx, bucket); err != nil {
+		return err
+	}
+	query := request.URL.Query()
+	uploadID := query.Get("uploadId")
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		return s.getObject(ctx, writer, request, bucket, key)
+	case http.MethodPut:
+		if uploadID != "" {
+			return s.uploadPart(ctx, writer, request, bucket, key, uploadID, query.Get("partNumber"))
+		}
+		return s.putObject(ctx, writer, request, bucket, key)
+	case http.MethodDelete:
+		if uploadID != "" {
+			return s.abortUpload(ctx, writer, bucket, key, uploadID)
+		}
+		return s.deleteObject(ctx, writer, bucket, key)
+	case http.MethodPost:
+		if _, ok := query["uploads"]; ok {
+			return s.createUpload(ctx, writer, bucket, key)
+		}
+		if uploadID != "" {
+			return s.completeUpload(ctx, writer, request, bucket, key, uploadID)
+		}
+	}
+	return newError(http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported object method: "+request.Method)
+}
+
+//checkBucket returns NoSuchBucket error if bucket folder does not exist
+func (s *Server) checkBucket(ctx context.Context, bucket string) error {
+	if !isValidBucket(bucket) {
+		return newError(http.StatusNotFound, "NoSuchBucket", "the specified bucket does not exist: "+bucket)
+	}
+	object, err := s.fs.Object(ctx, s.bucketURL(bucket), s.options...)
+	if err != nil || !object.IsDir() {
+		return newError(http.StatusNotFound, "NoSuchBucket", "the specified bucket does not exist: "+bucket)
+	}
+	return nil
+}
+
+func (s *Server) bucketURL(bucket string) string {
+	return url.Join(s.baseURL, bucket)
+}
+
+func (s *Server) objectURL(bucket, key string) string {
+	return url.Join(s.baseURL, bucket, key)
+}
+
+func (s *Server) writeError(writer http.ResponseWriter, request *http.Request, err error) {
+	s3Error, ok := err.(*Error)
+	if !ok {
+		s3Error = newError(http.StatusInternalServerError, "InternalError", err.Error())
+	}
+	s3Error.Resource = request.URL.Path
+	if request.Method == http.MethodHead {
+		writer.WriteHeader(s3Error.status)
+		return
+	}
+	_ = writeXML(writer, s3Error.status, s3Error)
+}
+
+func writeXML(writer http.ResponseWriter, status int, value interface{}) error {
+	data, err := xml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	writer.Header().Set("Content-Type", xmlContentType)
+	writer.Header().Set("Content-Length", fmt.Sprintf("%d", len(xml.Header)+len(data)))
+	writer.WriteHeader(status)
+	if _, err = writer.Write([]byte(xml.Header)); err == nil {
+		_, err = writer.Write(data)
+	}
+	return err
+}
+
+//bucketKey returns bucket and object key for path style request path
+func bucketKey(URLPath string) (string, string) {
+	URLPath = strings.TrimPrefix(URLPath, "/")
+	if index := strings.Index(URLPath, "/"); index != -1 {
+		return URLPath[:index], URLPath[index+1:]
+	}
+	return URLPath, ""
+}
+
+//objectETag returns quoted object content MD5, computed tag is reused until object size or modification time changes
+func (s *Server) objectETag(ctx context.Context, object storage.Object) (string, error) {
+	if value, ok := s.cachedETag(object); ok {
+		return value, nil
+	}
+	hash, err := s.fs.Checksum(ctx, object.URL(), checksum.MD5, s.options...)
+	if err != nil {
+		return "", err
+	}
+	value := `"` + hex.EncodeToString(hash) + `"`
+	s.setETag(object, value)
+	return value, nil
+}
+
+//cachedETag returns cached entity tag if object size and modification time have not changed
+func (s *Server) cachedETag(object storage.Object) (string, bool) {
+	if cached, ok := s.etags.Load(object.URL()); ok {
+		if tag := cached.(*entityTag); tag.size == object.Size() && tag.modTime.Equal(object.ModTime()) {
+			return tag.value, true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) setETag(object storage.Object, value string) {
+	s.etags.Store(object.URL(), &entityTag{size: object.Size(), modTime: object.ModTime(), value: value})
+}
+
+//New creates S3 compatible handler for supplied service base URL
+func New(fs afs.Service, baseURL string, options ...storage.Option) *Server {
+	return &Server{fs: fs, baseURL: url.Normalize(baseURL, file.Scheme), options: options}
+}
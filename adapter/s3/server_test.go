@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//send sends request to test server and returns response status, headers and body
+func send(t *testing.T, method, URL string, body io.Reader, header http.Header) (int, http.Header, string) {
+	request, err := http.NewRequest(method, URL, body)
+	if !assert.Nil(t, err) {
+		return 0, nil, ""
+	}
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		return 0, nil, ""
+	}
+	defer response.Body.Close()
+	data, _ := ioutil.ReadAll(response.Body)
+	return response.StatusCode, response.Header, string(data)
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseURL := "mem://localhost/s3server"
+	err := fs.Upload(ctx, baseURL+"/existing/folder/a.txt", 0644, strings.NewReader("abcdef"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	server := httptest.NewServer(New(fs, baseURL))
+	defer server.Close()
+
+	var useCases = []struct {
+		description  string
+		method       string
+		path         string
+		body         string
+		header       http.Header
+		expectStatus int
+		expect       string
+		expectETag   string
+	}{
+		{description: "list buckets", method: http.MethodGet, path: "/", expectStatus: http.StatusOK, expect: "<Name>existing</Name>"},
+		{description: "create bucket", method: http.MethodPut, path: "/created", expectStatus: http.StatusOK},
+		{description: "create existing bucket", method: http.MethodPut, path: "/created", expectStatus: http.StatusConflict, expect: "BucketAlreadyOwnedByYou"},
+		{description: "create invalid bucket", method: http.MethodPut, path: "/.hidden", expectStatus: http.StatusBadRequest, expect: "InvalidBucketName"},
+		{description: "head bucket", method: http.MethodHead, path: "/created", expectStatus: http.StatusOK},
+		{description: "head missing bucket", method: http.MethodHead, path: "/missing", expectStatus: http.StatusNotFound},
+		{description: "bucket location", method: http.MethodGet, path: "/created?location", expectStatus: http.StatusOK, expect: "LocationConstraint"},
+		{description: "put object", method: http.MethodPut, path: "/created/dir/b.txt", body: "0123456789", expectStatus: http.StatusOK, expectETag: `"781e5e245d69b566979b86e28d23f2c7"`},
+		{description: "get object", method: http.MethodGet, path: "/created/dir/b.txt", expectStatus: http.StatusOK, expect: "0123456789", expectETag: `"781e5e245d69b566979b86e28d23f2c7"`},
+		{description: "get range", method: http.MethodGet, path: "/created/dir/b.txt", header: http.Header{"Range": []string{"bytes=3-5"}}, expectStatus: http.StatusPartialContent, expect: "345"},
+		{description: "get suffix range", method: http.MethodGet, path: "/created/dir/b.txt", header: http.Header{"Range": []string{"bytes=-2"}}, expectStatus: http.StatusPartialContent, expect: "89"},
+		{description: "head object", method: http.MethodHead, path: "/created/dir/b.txt", expectStatus: http.StatusOK, expectETag: `"781e5e245d69b566979b86e28d23f2c7"`},
+		{description: "get existing object", method: http.MethodGet, path: "/existing/folder/a.txt", expectStatus: http.StatusOK, expect: "abcdef"},
+		{description: "get existing object with streamed entity tag", method: http.MethodGet, path: "/existing/folder/a.txt", expectStatus: http.StatusOK, expect: "abcdef", expectETag: `"e80b5017098950fc58aad83c8c14978e"`},
+		{description: "get range of streamed object", method: http.MethodGet, path: "/existing/folder/a.txt", header: http.Header{"Range": []string{"bytes=2-3"}}, expectStatus: http.StatusPartialContent, expect: "cd", expectETag: `"e80b5017098950fc58aad83c8c14978e"`},
+		{description: "get missing object", method: http.MethodGet, path: "/created/dir/z.txt", expectStatus: http.StatusNotFound, expect: "NoSuchKey"},
+		{description: "get from missing bucket", method: http.MethodGet, path: "/missing/a.txt", expectStatus: http.StatusNotFound, expect: "NoSuchBucket"},
+		{description: "get escaping key", method: http.MethodGet, path: "/created/dir/%2e%2e/%2e%2e/existing/folder/a.txt", expectStatus: http.StatusBadRequest, expect: "InvalidArgument"},
+		{description: "delete non empty bucket", method: http.MethodDelete, path: "/created", expectStatus: http.StatusConflict, expect: "BucketNotEmpty"},
+		{description: "delete object", method: http.MethodDelete, path: "/created/dir/b.txt", expectStatus: http.StatusNoContent},
+		{description: "delete missing object", method: http.MethodDelete, path: "/created/dir/b.txt", expectStatus: http.StatusNoContent},
+		{description: "delete objects", method: http.MethodPost, path: "/existing?delete", body: `<Delete><Object><Key>folder/a.txt</Key></Object></Delete>`, expectStatus: http.StatusOK, expect: "<Deleted><Key>folder/a.txt</Key></Deleted>"},
+		{description: "get deleted object", method: http.MethodGet, path: "/existing/folder/a.txt", expectStatus: http.StatusNotFound, expect: "NoSuchKey"},
+		{description: "unsupported method", method: http.MethodPatch, path: "/created/dir/b.txt", expectStatus: http.StatusMethodNotAllowed, expect: "MethodNotAllowed"},
+	}
+	for _, useCase := range useCases {
+		status, header, body := send(t, useCase.method, server.URL+useCase.path, strings.NewReader(useCase.body), useCase.header)
+		assert.EqualValues(t, useCase.expectStatus, status, useCase.description+": "+body)
+		if useCase.expect != "" {
+			assert.True(t, strings.Contains(body, useCase.expect), useCase.description+": "+body)
+		}
+		if useCase.expectETag != "" {
+			assert.EqualValues(t, useCase.expectETag, header.Get("ETag"), useCase.description)
+		}
+	}
+}
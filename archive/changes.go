@@ -0,0 +1,146 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/viant/afs/asset"
+	"github.com/viant/afs/file"
+	"io"
+	"path"
+	"strings"
+)
+
+//Changes represents archive entries modifications applied in a single archive pass
+type Changes struct {
+	deleted  []string
+	moved    []*move
+	added    []*asset.Resource
+	contents map[*asset.Resource]*io.SectionReader
+	matched  map[string]bool
+}
+
+type move struct {
+	source string
+	dest   string
+}
+
+//Delete removes location with its descendants
+func (c *Changes) Delete(location string) {
+	c.deleted = append(c.deleted, strings.Trim(location, "/"))
+}
+
+//Move renames location with its descendants
+func (c *Changes) Move(source, dest string) {
+	c.moved = append(c.moved, &move{source: strings.Trim(source, "/"), dest: strings.Trim(dest, "/")})
+}
+
+//Add adds resources, existing file resources are replaced and appended at the end of archive
+func (c *Changes) Add(resources ...*asset.Resource) {
+	for _, resource := range resources {
+		added := *resource
+		added.Name = strings.Trim(resource.Name, "/")
+		c.added = append(c.added, &added)
+	}
+}
+
+//AddContent adds file resource with content read from supplied section
+func (c *Changes) AddContent(resource *asset.Resource, content *io.SectionReader) {
+	c.Add(resource)
+	c.contents[c.added[len(c.added)-1]] = content
+}
+
+//Content returns added resource content and its size
+func (c *Changes) Content(resource *asset.Resource) (io.Reader, int64) {
+	if content, ok := c.contents[resource]; ok {
+		return io.NewSectionReader(content, 0, content.Size()), content.Size()
+	}
+	return bytes.NewReader(resource.Data), int64(len(resource.Data))
+}
+
+//IsEmpty returns true if there is no changes
+func (c *Changes) IsEmpty() bool {
+	return len(c.deleted) == 0 && len(c.moved) == 0 && len(c.added) == 0
+}
+
+//Rename returns entry name after changes, false if entry has to be removed
+func (c *Changes) Rename(name string) (string, bool) {
+	name = strings.Trim(name, "/")
+	for _, location := range c.deleted {
+		if isDescendant(location, name) {
+			c.matched[location] = true
+			return "", false
+		}
+	}
+	for _, moved := range c.moved {
+		if isDescendant(moved.source, name) {
+			c.matched[moved.source] = true
+			name = moved.dest + name[len(moved.source):]
+			return name, !c.isReplaced(name)
+		}
+		if isDescendant(moved.dest, name) {
+			return "", false
+		}
+	}
+	return name, !c.isReplaced(name)
+}
+
+//Validate returns an error if deleted or moved location was not found
+func (c *Changes) Validate() error {
+	for _, location := range c.deleted {
+		if !c.matched[location] {
+			return fmt.Errorf("%v: not found", location)
+		}
+	}
+	for _, moved := range c.moved {
+		if !c.matched[moved.source] {
+			return fmt.Errorf("%v: not found", moved.source)
+		}
+	}
+	return nil
+}
+
+//Additions returns resources to append with missing parent directories, existing represents names of already written entries
+func (c *Changes) Additions(existing map[string]bool) []*asset.Resource {
+	var result = make([]*asset.Resource, 0)
+	for _, moved := range c.moved {
+		result = appendParents(result, existing, moved.dest)
+	}
+	for _, resource := range c.added {
+		result = appendParents(result, existing, resource.Name)
+		if resource.Dir && existing[resource.Name] {
+			continue
+		}
+		existing[resource.Name] = true
+		result = append(result, resource)
+	}
+	return result
+}
+
+func (c *Changes) isReplaced(name string) bool {
+	for _, resource := range c.added {
+		if !resource.Dir && resource.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func appendParents(resources []*asset.Resource, existing map[string]bool, location string) []*asset.Resource {
+	parent, _ := path.Split(location)
+	parent = strings.Trim(parent, "/")
+	if parent == "" || existing[parent] {
+		return resources
+	}
+	resources = appendParents(resources, existing, parent)
+	existing[parent] = true
+	return append(resources, asset.New(parent, file.DefaultDirOsMode, true, "", nil))
+}
+
+func isDescendant(location, name string) bool {
+	return name == location || strings.HasPrefix(name, location+"/")
+}
+
+//NewChanges returns archive changes
+func NewChanges() *Changes {
+	return &Changes{matched: make(map[string]bool), contents: make(map[*asset.Resource]*io.SectionReader)}
+}
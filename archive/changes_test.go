@@ -0,0 +1,96 @@
+package archive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/asset"
+	"testing"
+)
+
+func TestChanges_Rename(t *testing.T) {
+
+	var useCases = []struct {
+		description string
+		changes     func(changes *Changes)
+		entries     []string
+		expect      []string
+		additions   []string
+		hasError    bool
+	}{
+		{
+			description: "delete folder",
+			changes: func(changes *Changes) {
+				changes.Delete("f1")
+			},
+			entries: []string{"res1.txt", "f1/", "f1/res2.txt", "f10/res3.txt"},
+			expect:  []string{"res1.txt", "f10/res3.txt"},
+		},
+		{
+			description: "move folder",
+			changes: func(changes *Changes) {
+				changes.Move("f1", "f2/sub")
+			},
+			entries:   []string{"res1.txt", "f1/", "f1/res2.txt"},
+			expect:    []string{"res1.txt", "f2/sub", "f2/sub/res2.txt"},
+			additions: []string{"f2"},
+		},
+		{
+			description: "move file over existing",
+			changes: func(changes *Changes) {
+				changes.Move("res1.txt", "res2.txt")
+			},
+			entries: []string{"res1.txt", "res2.txt"},
+			expect:  []string{"res2.txt"},
+		},
+		{
+			description: "replace and add file",
+			changes: func(changes *Changes) {
+				changes.Add(asset.NewFile("f1/res2.txt", []byte("xyz"), 0644), asset.NewFile("f3/sub/res4.txt", []byte("xyz"), 0644), asset.NewDir("f1", 0755))
+			},
+			entries:   []string{"res1.txt", "f1/res2.txt"},
+			expect:    []string{"res1.txt"},
+			additions: []string{"f1", "f1/res2.txt", "f3", "f3/sub", "f3/sub/res4.txt"},
+		},
+		{
+			description: "delete missing location",
+			changes: func(changes *Changes) {
+				changes.Delete("f5")
+			},
+			entries:  []string{"res1.txt"},
+			expect:   []string{"res1.txt"},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		changes := NewChanges()
+		useCase.changes(changes)
+		assert.False(t, changes.IsEmpty(), useCase.description)
+		var actual = make([]string, 0)
+		var existing = make(map[string]bool)
+		for _, entry := range useCase.entries {
+			name, ok := changes.Rename(entry)
+			if !ok {
+				continue
+			}
+			existing[name] = true
+			actual = append(actual, name)
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		err := changes.Validate()
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var additions = make([]string, 0)
+		for _, resource := range changes.Additions(existing) {
+			additions = append(additions, resource.Name)
+		}
+		if len(useCase.additions) == 0 {
+			useCase.additions = []string{}
+		}
+		assert.EqualValues(t, useCase.additions, additions, useCase.description)
+	}
+}
@@ -0,0 +1,65 @@
+package archive
+
+import (
+	"github.com/viant/afs/file"
+	"os"
+	"path"
+	"strings"
+)
+
+//Listing filters archive entries to a location and its direct children
+type Listing struct {
+	location string
+	listed   map[string]bool
+}
+
+//ListingEntry represents listed archive entry with its parent
+type ListingEntry struct {
+	os.FileInfo
+	Parent     string
+	IsLocation bool
+}
+
+//Entries returns listing entries for supplied archive entry: location itself, its direct child or implicit directories of nested entries,
+//missing location directory is created for its descendants, each entry is listed once
+func (l *Listing) Entries(parent string, info os.FileInfo) []*ListingEntry {
+	entryPath := strings.Trim(path.Join(parent, info.Name()), "/")
+	if entryPath == l.location {
+		return l.add(entryPath, &ListingEntry{FileInfo: info, Parent: parent, IsLocation: true})
+	}
+	relative := entryPath
+	childParent := ""
+	if l.location != "" {
+		if !strings.HasPrefix(entryPath, l.location+"/") {
+			return nil
+		}
+		relative = entryPath[len(l.location)+1:]
+		childParent = l.location + "/"
+	}
+	var result []*ListingEntry
+	if l.location != "" && !l.listed[l.location] {
+		locationParent, name := path.Split(l.location)
+		result = l.add(l.location, &ListingEntry{FileInfo: file.NewInfo(name, 0, file.DefaultDirOsMode, info.ModTime(), true), Parent: locationParent, IsLocation: true})
+	}
+	child := relative
+	if index := strings.Index(relative, "/"); index != -1 {
+		child = relative[:index]
+		info = file.NewInfo(child, 0, file.DefaultDirOsMode, info.ModTime(), true)
+	} else if info.Name() == "" { //directory entry
+		info = file.NewInfo(child, info.Size(), info.Mode(), info.ModTime(), info.IsDir())
+	}
+	return append(result, l.add(path.Join(l.location, child), &ListingEntry{FileInfo: info, Parent: childParent})...)
+}
+
+func (l *Listing) add(entryPath string, entry *ListingEntry) []*ListingEntry {
+	if l.listed[entryPath] {
+		return nil
+	}
+	l.listed[entryPath] = true
+	return []*ListingEntry{entry}
+}
+
+//NewListing creates a location listing
+func NewListing(location string) *Listing {
+	return &Listing{location: strings.Trim(location, "/"), listed: make(map[string]bool)}
+}
@@ -0,0 +1,69 @@
+package archive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestListing_Entries(t *testing.T) {
+	entries := []string{"test/", "test/a.txt", "test/sub/", "test/sub/b.txt", "test/sub/", "other.txt"}
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+	}{
+		{description: "root", location: "", expect: []string{"test", "other.txt"}},
+		{description: "folder", location: "/test", expect: []string{"", "a.txt", "sub"}},
+		{description: "nested folder", location: "test/sub", expect: []string{"", "b.txt"}},
+		{description: "file", location: "test/a.txt", expect: []string{"a.txt"}},
+		{description: "missing", location: "test/z.txt"},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, listEntries(useCase.location, entries), useCase.description)
+	}
+}
+
+func TestListing_Entries_Implicit(t *testing.T) {
+	entries := []string{"a.txt", "sub/b.txt", "sub/deep/c.txt"}
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+		expectDirs  []bool
+	}{
+		{description: "root", location: "", expect: []string{"a.txt", "sub"}, expectDirs: []bool{false, true}},
+		{description: "implicit folder", location: "sub", expect: []string{"sub", "sub/b.txt", "sub/deep"}, expectDirs: []bool{true, false, true}},
+		{description: "nested implicit folder", location: "sub/deep", expect: []string{"sub/deep", "sub/deep/c.txt"}, expectDirs: []bool{true, false}},
+		{description: "missing", location: "other"},
+	}
+	for _, useCase := range useCases {
+		listing := NewListing(useCase.location)
+		var actual []string
+		var actualDirs []bool
+		for _, entry := range entries {
+			parent, name := path.Split(entry)
+			for _, entry := range listing.Entries(parent, file.NewInfo(name, 0, file.DefaultFileOsMode, time.Now(), false)) {
+				actual = append(actual, path.Join(entry.Parent, entry.Name()))
+				actualDirs = append(actualDirs, entry.IsDir())
+			}
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		assert.EqualValues(t, useCase.expectDirs, actualDirs, useCase.description)
+	}
+}
+
+func listEntries(location string, entries []string) []string {
+	listing := NewListing(location)
+	var result []string
+	for _, entry := range entries {
+		parent, name := path.Split(entry)
+		info := file.NewInfo(name, 0, file.DefaultFileOsMode, time.Now(), name == "")
+		for _, listed := range listing.Entries(parent, info) {
+			result = append(result, listed.Name())
+		}
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/delta"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+//Modification represents archive modification, it writes modified archive using stored archive as base
+type Modification func(base io.ReaderAt, size int64, writer io.Writer) error
+
+//Modify rewrites stored archive with supplied modification, managers implementing delta.Patcher modify archive in place,
+//otherwise base and modified archives are spooled to temp files to keep memory bounded
+func Modify(ctx context.Context, manager storage.Manager, URL string, mode os.FileMode, modification Modification) error {
+	objects, err := manager.List(ctx, URL)
+	if err != nil {
+		return err
+	}
+	if len(objects) != 1 || objects[0].IsDir() {
+		return fmt.Errorf("%v: not found", URL)
+	}
+	size := objects[0].Size()
+	if patcher, ok := manager.(delta.Patcher); ok {
+		return patcher.Patch(ctx, URL, func(base io.ReaderAt, writer io.Writer) error {
+			return modification(base, size, writer)
+		})
+	}
+	reader, err := manager.OpenURL(ctx, URL)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	base, ok := reader.(io.ReaderAt)
+	if !ok {
+		spool, err := newSpool()
+		if err != nil {
+			return err
+		}
+		defer spool.remove()
+		if size, err = io.Copy(spool, reader); err != nil {
+			return err
+		}
+		base = spool
+	}
+	modified, err := newSpool()
+	if err != nil {
+		return err
+	}
+	defer modified.remove()
+	if err = modification(base, size, modified); err != nil {
+		return err
+	}
+	if _, err = modified.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return manager.Upload(ctx, URL, mode, modified)
+}
+
+//spool represents temp file
+type spool struct {
+	*os.File
+}
+
+func (s *spool) remove() {
+	_ = s.Close()
+	_ = os.Remove(s.Name())
+}
+
+func newSpool() (*spool, error) {
+	temp, err := ioutil.TempFile("", "afs_archive")
+	if err != nil {
+		return nil, err
+	}
+	return &spool{File: temp}, nil
+}
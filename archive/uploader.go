@@ -3,6 +3,7 @@ package archive
 import (
 	"context"
 	"github.com/viant/afs/asset"
+	"github.com/viant/afs/file"
 	"io"
 	"io/ioutil"
 	"os"
@@ -42,3 +43,54 @@ func NewRewriteUploader(listener func(resources []*asset.Resource) error) *Rewri
 		listener:  listener,
 	}
 }
+
+//SpoolUploader represents batch uploader spooling uploaded content to a temp file
+type SpoolUploader struct {
+	destination string
+	spool       *spool
+	size        int64
+	changes     *Changes
+	listener    func(changes *Changes) error
+}
+
+//Upload spools uploaded content and records it as archive addition
+func (u *SpoolUploader) Upload(ctx context.Context, parent string, info os.FileInfo, reader io.Reader) error {
+	resource := asset.New(path.Join(u.destination, parent, info.Name()), info.Mode(), info.IsDir(), "", nil)
+	resource.FileInfo = info
+	if info.IsDir() {
+		u.changes.Add(resource)
+		return nil
+	}
+	if u.spool == nil {
+		spool, err := newSpool()
+		if err != nil {
+			return err
+		}
+		u.spool = spool
+	}
+	written, err := io.Copy(u.spool, reader)
+	if err != nil {
+		return err
+	}
+	resource.FileInfo = file.NewInfo(info.Name(), written, info.Mode(), info.ModTime(), false)
+	u.changes.AddContent(resource, io.NewSectionReader(u.spool, u.size, written))
+	u.size += written
+	return nil
+}
+
+//Close notifies specified listener and removes spooled content
+func (u *SpoolUploader) Close() error {
+	if u.spool != nil {
+		defer u.spool.remove()
+	}
+	return u.listener(u.changes)
+}
+
+//NewSpoolUploader returns new spool uploader, uploaded resources are added under destination
+func NewSpoolUploader(destination string, listener func(changes *Changes) error) *SpoolUploader {
+	return &SpoolUploader{
+		destination: destination,
+		changes:     NewChanges(),
+		listener:    listener,
+	}
+}
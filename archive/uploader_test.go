@@ -0,0 +1,62 @@
+package archive
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/asset"
+	"io/ioutil"
+	"path"
+	"testing"
+)
+
+func TestSpoolUploader_Upload(t *testing.T) {
+	ctx := context.Background()
+
+	var useCases = []struct {
+		description string
+		destination string
+		resources   []*asset.Resource
+		expect      map[string]string
+	}{
+		{
+			description: "files spooled",
+			resources: []*asset.Resource{
+				asset.NewFile("res1.txt", []byte("this is test 1"), 0644),
+				asset.NewFile("folder1/res2.txt", []byte("this is test 2"), 0644),
+			},
+			expect: map[string]string{"res1.txt": "this is test 1", "folder1": "", "folder1/res2.txt": "this is test 2"},
+		},
+		{
+			description: "files with directory under destination",
+			destination: "dest",
+			resources: []*asset.Resource{
+				asset.NewDir("folder1", 0755),
+				asset.NewFile("folder1/res1.txt", []byte("this is test 1"), 0644),
+			},
+			expect: map[string]string{"dest": "", "dest/folder1": "", "dest/folder1/res1.txt": "this is test 1"},
+		},
+	}
+
+	for _, useCase := range useCases {
+		var actual = make(map[string]string)
+		uploader := NewSpoolUploader(useCase.destination, func(changes *Changes) error {
+			for _, resource := range changes.Additions(make(map[string]bool)) {
+				content, size := changes.Content(resource)
+				data, err := ioutil.ReadAll(content)
+				if err != nil {
+					return err
+				}
+				assert.EqualValues(t, len(data), size, useCase.description)
+				actual[resource.Name] = string(data)
+			}
+			return nil
+		})
+		for _, resource := range useCase.resources {
+			parent, _ := path.Split(resource.Name)
+			err := uploader.Upload(ctx, parent, resource.Info(), resource.Reader())
+			assert.Nil(t, err, useCase.description)
+		}
+		assert.Nil(t, uploader.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
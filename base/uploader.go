@@ -9,7 +9,7 @@ import (
 	"os"
 	"path"
 	"strings"
-	"sync/atomic"
+	"sync"
 )
 
 type uploader struct {
@@ -22,19 +22,23 @@ func (u *uploader) Close() error {
 }
 
 func (u *uploader) Uploader(ctx context.Context, URL string, options ...storage.Option) (storage.Upload, io.Closer, error) {
-	index := int32(0)
+	index := 0
+	mux := &sync.Mutex{}
 	handler := func(ctx context.Context, parent string, info os.FileInfo, reader io.Reader) error {
 		location := path.Join(parent, info.Name())
-
-		if atomic.AddInt32(&index, 1) == 1 {
+		mux.Lock()
+		if index++; index == 1 {
 			if strings.HasSuffix(URL, location) {
 				URL = string(URL[:len(URL)-len(location)])
 			}
 		}
-		URL := url.Join(URL, location)
+		baseURL := URL
+		mux.Unlock()
+		URL := url.Join(baseURL, location)
+		options := options
 		if info.Mode()&os.ModeSymlink > 0 {
 			if rawInfo, ok := info.(*file.Info); ok && rawInfo.Linkname != "" {
-				options = append(options, rawInfo.Link)
+				options = append(options[:len(options):len(options)], rawInfo.Link)
 			}
 		}
 		if info.IsDir() {
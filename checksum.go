@@ -0,0 +1,32 @@
+package afs
+
+import (
+	"context"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+)
+
+//Checksum returns URL content checksum, managers implementing storage.Hasher answer without reading content if checksum is known
+func (s *service) Checksum(ctx context.Context, URL string, algorithm string, options ...storage.Option) ([]byte, error) {
+	URL = url.Normalize(URL, file.Scheme)
+	if _, err := checksum.New(algorithm); err != nil {
+		return nil, err
+	}
+	manager, err := s.manager(ctx, URL, options)
+	if err != nil {
+		return nil, err
+	}
+	if hasher, ok := manager.(storage.Hasher); ok {
+		if result, err := hasher.Checksum(ctx, URL, algorithm, options...); err != nil || len(result) > 0 {
+			return result, err
+		}
+	}
+	reader, err := manager.OpenURL(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return checksum.Compute(reader, algorithm)
+}
@@ -0,0 +1,47 @@
+package checksum
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+const (
+	//MD5 represents md5 algorithm
+	MD5 = "md5"
+	//SHA256 represents sha-256 algorithm
+	SHA256 = "sha256"
+	//CRC32C represents crc32 with Castagnoli polynomial, checksum is big endian encoded
+	CRC32C = "crc32c"
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+//New returns a hash for supplied algorithm
+func New(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case MD5:
+		return md5.New(), nil
+	case SHA256:
+		return sha256.New(), nil
+	case CRC32C:
+		return crc32.New(castagnoli), nil
+	}
+	return nil, fmt.Errorf("unsupported checksum algorithm: %v", algorithm)
+}
+
+//Compute reads content and returns its checksum
+func Compute(reader io.Reader, algorithm string) ([]byte, error) {
+	hash, err := New(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(hash, reader); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
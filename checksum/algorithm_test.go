@@ -0,0 +1,35 @@
+package checksum
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	var useCases = []struct {
+		description string
+		algorithm   string
+		content     string
+		expect      string
+		hasError    bool
+	}{
+		{description: "md5", algorithm: MD5, content: "abc", expect: "900150983cd24fb0d6963f7d28e17f72"},
+		{description: "sha256", algorithm: SHA256, content: "abc", expect: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{description: "crc32c", algorithm: CRC32C, content: "123456789", expect: "e3069283"},
+		{description: "upper case algorithm", algorithm: "MD5", content: "abc", expect: "900150983cd24fb0d6963f7d28e17f72"},
+		{description: "unsupported algorithm", algorithm: "sha1", content: "abc", hasError: true},
+	}
+	for _, useCase := range useCases {
+		actual, err := Compute(strings.NewReader(useCase.content), useCase.algorithm)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, hex.EncodeToString(actual), useCase.description)
+	}
+}
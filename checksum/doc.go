@@ -0,0 +1,2 @@
+//Package checksum defines supported content checksum algorithms
+package checksum
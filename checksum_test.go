@@ -0,0 +1,59 @@
+package afs
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/checksum"
+	"hash/crc32"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestService_Checksum(t *testing.T) {
+	ctx := context.Background()
+	service := New()
+	const content = "checksum test content"
+	md5Digest := md5.Sum([]byte(content))
+	sha256Digest := sha256.Sum256([]byte(content))
+	crc32cDigest := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	_, _ = crc32cDigest.Write([]byte(content))
+	fileURL := path.Join(os.TempDir(), "afs_checksum", "asset.txt")
+	memURL := "mem://localhost/checksum/asset.txt"
+	for _, URL := range []string{fileURL, memURL} {
+		if !assert.Nil(t, service.Upload(ctx, URL, 0644, strings.NewReader(content))) {
+			return
+		}
+	}
+	defer func() { _ = service.Delete(ctx, path.Join(os.TempDir(), "afs_checksum")) }()
+
+	var useCases = []struct {
+		description string
+		URL         string
+		algorithm   string
+		expect      []byte
+		hasError    bool
+	}{
+		{description: "file md5", URL: fileURL, algorithm: checksum.MD5, expect: md5Digest[:]},
+		{description: "file sha256", URL: fileURL, algorithm: checksum.SHA256, expect: sha256Digest[:]},
+		{description: "file crc32c", URL: fileURL, algorithm: checksum.CRC32C, expect: crc32cDigest.Sum(nil)},
+		{description: "mem md5", URL: memURL, algorithm: checksum.MD5, expect: md5Digest[:]},
+		{description: "mem sha256", URL: memURL, algorithm: "SHA256", expect: sha256Digest[:]},
+		{description: "missing file", URL: path.Join(os.TempDir(), "afs_checksum", "missing.txt"), algorithm: checksum.MD5, hasError: true},
+		{description: "unsupported algorithm", URL: fileURL, algorithm: "sha1", hasError: true},
+	}
+	for _, useCase := range useCases {
+		actual, err := service.Checksum(ctx, useCase.URL, useCase.algorithm)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
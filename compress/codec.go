@@ -0,0 +1,157 @@
+package compress
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"sync"
+)
+
+const (
+	//Gzip represents gzip codec
+	Gzip = "gzip"
+	//Bzip2 represents bzip2 codec, encoding is not supported
+	Bzip2 = "bzip2"
+	//Zstd represents zstd codec, it has to be registered with Register
+	Zstd = "zstd"
+	//Xz represents xz codec, it has to be registered with Register
+	Xz = "xz"
+	//gzipMaxSize represents max gzip content size with reliable trailer size
+	gzipMaxSize = 1 << 32
+)
+
+//Codec represents compression codec
+type Codec interface {
+	//Name returns codec name
+	Name() string
+	//NewReader returns decoding reader
+	NewReader(reader io.Reader) (io.ReadCloser, error)
+	//NewWriter returns encoding writer, Close flushes encoded content without closing underlying writer
+	NewWriter(writer io.Writer) (io.WriteCloser, error)
+}
+
+//Sizer represents codec able to recover uncompressed size from encoded content
+type Sizer interface {
+	//Size returns uncompressed size
+	Size(reader io.ReadSeeker, size int64) (int64, bool)
+}
+
+var registry = struct {
+	sync.RWMutex
+	codecs     map[string]Codec
+	extensions map[string]string
+}{
+	codecs:     make(map[string]Codec),
+	extensions: map[string]string{".gz": Gzip, ".gzip": Gzip, ".tgz": Gzip, ".bz2": Bzip2, ".zst": Zstd, ".xz": Xz},
+}
+
+//Register registers codec with optional file extensions, i.e. zstd or xz codec backed by third party library
+func Register(codec Codec, extensions ...string) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.codecs[codec.Name()] = codec
+	for _, ext := range extensions {
+		registry.extensions[strings.ToLower(ext)] = codec.Name()
+	}
+}
+
+//Lookup returns codec for supplied name
+func Lookup(name string) (Codec, error) {
+	registry.RLock()
+	defer registry.RUnlock()
+	if codec, ok := registry.codecs[strings.ToLower(name)]; ok {
+		return codec, nil
+	}
+	return nil, fmt.Errorf("unsupported compression codec: %v, use compress.Register", name)
+}
+
+//Extension returns registered codec name for supplied URL extension or empty string, i.e. .zst and .xz are not mapped until codec is registered
+func Extension(URL string) string {
+	registry.RLock()
+	defer registry.RUnlock()
+	name := registry.extensions[strings.ToLower(path.Ext(URL))]
+	if _, ok := registry.codecs[name]; !ok {
+		return ""
+	}
+	return name
+}
+
+type gzipCodec struct{}
+
+func (c *gzipCodec) Name() string {
+	return Gzip
+}
+
+func (c *gzipCodec) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(reader)
+}
+
+func (c *gzipCodec) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(writer), nil
+}
+
+//Size returns uncompressed size of single member gzip content, content of 4GiB or more (gzip trailer size is modulo 2^32)
+//or with more than one member is reported as unknown
+func (c *gzipCodec) Size(reader io.ReadSeeker, size int64) (int64, bool) {
+	if size < 18 || size >= gzipMaxSize {
+		return 0, false
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return 0, false
+	}
+	counter := &byteCounter{Reader: bufio.NewReader(reader)}
+	gzipReader, err := gzip.NewReader(counter)
+	if err != nil {
+		return 0, false
+	}
+	gzipReader.Multistream(false)
+	decoded, err := io.Copy(ioutil.Discard, gzipReader)
+	if err != nil || counter.count != size {
+		return 0, false
+	}
+	return decoded, true
+}
+
+//byteCounter counts bytes consumed by gzip reader to detect first member end
+type byteCounter struct {
+	*bufio.Reader
+	count int64
+}
+
+func (c *byteCounter) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+func (c *byteCounter) ReadByte() (byte, error) {
+	b, err := c.Reader.ReadByte()
+	if err == nil {
+		c.count++
+	}
+	return b, err
+}
+
+type bzip2Codec struct{}
+
+func (c *bzip2Codec) Name() string {
+	return Bzip2
+}
+
+func (c *bzip2Codec) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bzip2.NewReader(reader)), nil
+}
+
+func (c *bzip2Codec) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("%v encoding is not supported", Bzip2)
+}
+
+func init() {
+	Register(&gzipCodec{})
+	Register(&bzip2Codec{})
+}
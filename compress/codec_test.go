@@ -0,0 +1,134 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+//prefixCodec represents test codec prefixing content
+type prefixCodec struct {
+	name string
+}
+
+func (c *prefixCodec) Name() string {
+	return c.name
+}
+
+func (c *prefixCodec) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	prefix := make([]byte, len(c.name))
+	if _, err := io.ReadFull(reader, prefix); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(reader), nil
+}
+
+func (c *prefixCodec) NewWriter(writer io.Writer) (io.WriteCloser, error) {
+	_, err := writer.Write([]byte(c.name))
+	return &nopWriteCloser{Writer: writer}, err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (w *nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestLookup(t *testing.T) {
+	Register(&prefixCodec{name: "prefix"}, ".pfx")
+	bzip2Data, _ := hex.DecodeString("425a6839314159265359555a44f70000021980400010001264c0102000220069ea100305d3b62183c5dc914e14241556913dc0")
+
+	var useCases = []struct {
+		description string
+		URL         string
+		codec       string
+		encoded     []byte
+		expect      string
+		hasError    bool
+	}{
+		{description: "gzip", URL: "/tmp/app.log.gz", codec: Gzip, expect: "gzip content"},
+		{description: "tgz", URL: "/tmp/app.tgz", codec: Gzip, expect: "tgz content"},
+		{description: "registered codec", URL: "/tmp/app.log.PFX", codec: "prefix", expect: "prefixed content"},
+		{description: "bzip2", URL: "/tmp/app.log.bz2", codec: Bzip2, encoded: bzip2Data, expect: "hello bzip2"},
+		{description: "bzip2 encoding", URL: "/tmp/app.log.bz2", codec: Bzip2, expect: "abc", hasError: true},
+		{description: "zstd not registered", URL: "/tmp/app.log.zst"},
+		{description: "xz not registered", URL: "/tmp/app.log.xz"},
+		{description: "no codec", URL: "/tmp/app.log"},
+	}
+	for _, useCase := range useCases {
+		name := Extension(useCase.URL)
+		assert.EqualValues(t, useCase.codec, name, useCase.description)
+		if name == "" {
+			continue
+		}
+		codec, err := Lookup(name)
+		if err == nil && useCase.encoded == nil {
+			buffer := new(bytes.Buffer)
+			var writer io.WriteCloser
+			if writer, err = codec.NewWriter(buffer); err == nil {
+				_, _ = writer.Write([]byte(useCase.expect))
+				err = writer.Close()
+			}
+			useCase.encoded = buffer.Bytes()
+		}
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := codec.NewReader(bytes.NewReader(useCase.encoded))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(actual), useCase.description)
+	}
+}
+
+func TestGzipCodec_Size(t *testing.T) {
+	encode := func(contents ...string) []byte {
+		buffer := new(bytes.Buffer)
+		for _, content := range contents {
+			writer := gzip.NewWriter(buffer)
+			_, _ = writer.Write([]byte(content))
+			_ = writer.Close()
+		}
+		return buffer.Bytes()
+	}
+	content := strings.Repeat("log line\n", 1000)
+
+	var useCases = []struct {
+		description string
+		encoded     []byte
+		size        int64
+		expect      int64
+		expectOk    bool
+	}{
+		{description: "single member", encoded: encode(content), expect: int64(len(content)), expectOk: true},
+		{description: "multi member", encoded: encode(content, "tail")},
+		{description: "4GiB compressed size", encoded: encode(content), size: 1 << 32},
+		{description: "too short", encoded: []byte{0x1f, 0x8b}},
+	}
+	codec := &gzipCodec{}
+	for _, useCase := range useCases {
+		size := useCase.size
+		if size == 0 {
+			size = int64(len(useCase.encoded))
+		}
+		actual, ok := codec.Size(bytes.NewReader(useCase.encoded), size)
+		assert.EqualValues(t, useCase.expectOk, ok, useCase.description)
+		if useCase.expectOk {
+			assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+//Package compress defines compression codecs and storage manager decorator encoding uploaded and decoding downloaded content
+package compress
@@ -0,0 +1,147 @@
+package compress
+
+import (
+	"context"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+)
+
+type manager struct {
+	storage.Manager
+	compression *option.Compression
+}
+
+//codec returns codec for option.Compression or URL extension, nil if content is not compressed
+func (m *manager) codec(URL string, options []storage.Option) (Codec, error) {
+	compression := &option.Compression{}
+	if _, ok := option.Assign(options, &compression); !ok || compression.Codec == "" {
+		compression = m.compression
+	}
+	name := compression.Codec
+	if name == "" {
+		if name = Extension(URL); name == "" {
+			return nil, nil
+		}
+	}
+	return Lookup(name)
+}
+
+//List lists underlying objects, uncompressed file size is recovered from encoded content with file delegate or option.Compression ListSize
+func (m *manager) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	objects, err := m.Manager.List(ctx, URL, options...)
+	if err != nil || !m.listSize(options) {
+		return objects, err
+	}
+	for i, candidate := range objects {
+		if candidate.IsDir() {
+			continue
+		}
+		codec, _ := m.codec(candidate.URL(), options)
+		sizer, ok := codec.(Sizer)
+		if !ok {
+			continue
+		}
+		if size, ok := m.size(ctx, candidate, sizer); ok {
+			info := file.NewInfo(candidate.Name(), size, candidate.Mode(), candidate.ModTime(), false)
+			objects[i] = object.New(candidate.URL(), info, candidate)
+		}
+	}
+	return objects, nil
+}
+
+//listSize returns true if delegate content can be opened cheaply to read trailer
+func (m *manager) listSize(options []storage.Option) bool {
+	compression := &option.Compression{}
+	if _, ok := option.Assign(options, &compression); ok && compression.ListSize {
+		return true
+	}
+	return m.compression.ListSize || m.Manager.Scheme() == file.Scheme
+}
+
+func (m *manager) size(ctx context.Context, candidate storage.Object, sizer Sizer) (int64, bool) {
+	reader, err := m.Manager.OpenURL(ctx, candidate.URL())
+	if err != nil {
+		return 0, false
+	}
+	defer func() { _ = reader.Close() }()
+	seeker, ok := reader.(io.ReadSeeker)
+	if !ok {
+		return 0, false
+	}
+	return sizer.Size(seeker, candidate.Size())
+}
+
+//Open returns decoding reader for supplied object
+func (m *manager) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
+	return m.OpenURL(ctx, object.URL(), options...)
+}
+
+//OpenURL returns decoding reader for supplied URL
+func (m *manager) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	codec, err := m.codec(URL, options)
+	if err != nil {
+		return nil, err
+	}
+	source, err := m.Manager.OpenURL(ctx, URL, options...)
+	if err != nil || codec == nil {
+		return source, err
+	}
+	reader, err := codec.NewReader(source)
+	if err != nil {
+		_ = source.Close()
+		return nil, err
+	}
+	return &readCloser{ReadCloser: reader, source: source}, nil
+}
+
+//Upload encodes and uploads content
+func (m *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	codec, err := m.codec(URL, options)
+	if err != nil {
+		return err
+	}
+	if codec == nil {
+		return m.Manager.Upload(ctx, URL, mode, reader, options...)
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	writer, err := codec.NewWriter(pipeWriter)
+	if err != nil {
+		return err
+	}
+	go func() {
+		_, err := io.Copy(writer, reader)
+		if err == nil {
+			err = writer.Close()
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	err = m.Manager.Upload(ctx, URL, mode, pipeReader, options...)
+	_ = pipeReader.CloseWithError(err)
+	return err
+}
+
+type readCloser struct {
+	io.ReadCloser
+	source io.Closer
+}
+
+//Close closes decoder and underlying reader
+func (r *readCloser) Close() error {
+	err := r.ReadCloser.Close()
+	if closeErr := r.source.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//New returns manager decorator compressing uploaded and decompressing downloaded content,
+//codec is taken from option.Compression (call or constructor option) or URL extension (.gz, .bz2 or registered codec extension)
+func New(delegate storage.Manager, options ...storage.Option) storage.Manager {
+	compression := &option.Compression{}
+	option.Assign(options, &compression)
+	return &manager{Manager: delegate, compression: compression}
+}
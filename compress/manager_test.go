@@ -0,0 +1,117 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestManager_Upload(t *testing.T) {
+	ctx := context.Background()
+	baseDir := path.Join(os.TempDir(), "afs_compress")
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	content := strings.Repeat("log line\n", 1000)
+
+	var useCases = []struct {
+		description    string
+		manager        storage.Manager
+		URL            string
+		options        []storage.Option
+		uploadOptions  []storage.Option
+		expectGzip     bool
+		expectListSize int
+	}{
+		{description: "file gz extension", manager: New(file.New()), URL: path.Join(baseDir, "app.log.gz"), expectGzip: true, expectListSize: len(content)},
+		{description: "mem gz extension", manager: New(mem.New()), URL: "mem://localhost/compress/app.log.gz", expectGzip: true},
+		{description: "unregistered zst extension", manager: New(mem.New()), URL: "mem://localhost/compress/app.log.zst", expectListSize: len(content)},
+		{description: "call option", manager: New(file.New()), URL: path.Join(baseDir, "app.log"), uploadOptions: []storage.Option{option.NewCompression(Gzip)}, expectGzip: true, expectListSize: len(content)},
+		{description: "constructor option", manager: New(mem.New(), option.NewCompression(Gzip)), URL: "mem://localhost/compress/app.data", expectGzip: true},
+		{description: "not compressed", manager: New(mem.New()), URL: "mem://localhost/compress/app.log", expectListSize: len(content)},
+	}
+
+	for _, useCase := range useCases {
+		err := useCase.manager.Upload(ctx, useCase.URL, file.DefaultFileOsMode, strings.NewReader(content), useCase.uploadOptions...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		raw, err := useCase.manager.(*manager).Manager.OpenURL(ctx, useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		encoded, _ := ioutil.ReadAll(raw)
+		_ = raw.Close()
+		_, gzipErr := gzip.NewReader(bytes.NewReader(encoded))
+		assert.EqualValues(t, useCase.expectGzip, gzipErr == nil, useCase.description)
+
+		objects, err := useCase.manager.List(ctx, useCase.URL, useCase.uploadOptions...)
+		if assert.Nil(t, err, useCase.description) && assert.EqualValues(t, 1, len(objects), useCase.description) {
+			expectSize := int64(useCase.expectListSize)
+			if expectSize == 0 {
+				expectSize = int64(len(encoded))
+			}
+			assert.EqualValues(t, expectSize, objects[0].Size(), useCase.description)
+		}
+
+		reader, err := useCase.manager.OpenURL(ctx, useCase.URL, useCase.uploadOptions...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, useCase.description)
+		assert.Nil(t, reader.Close(), useCase.description)
+		assert.EqualValues(t, content, string(actual), useCase.description)
+	}
+
+	err := New(mem.New(), option.NewCompression(Zstd)).Upload(ctx, "mem://localhost/compress/app.log.zst", file.DefaultFileOsMode, strings.NewReader(content))
+	assert.NotNil(t, err)
+}
+
+func TestManager_List(t *testing.T) {
+	ctx := context.Background()
+	content := strings.Repeat("log line\n", 100)
+
+	var useCases = []struct {
+		description string
+		options     []storage.Option
+		listOptions []storage.Option
+		expectOpens int
+	}{
+		{description: "remote delegate content is not opened"},
+		{description: "constructor list size option", options: []storage.Option{&option.Compression{ListSize: true}}, expectOpens: 1},
+		{description: "call list size option", listOptions: []storage.Option{&option.Compression{ListSize: true}}, expectOpens: 1},
+	}
+	for _, useCase := range useCases {
+		delegate := &openCounter{Manager: mem.New()}
+		manager := New(delegate, useCase.options...)
+		URL := "mem://localhost/compress/list/app.log.gz"
+		if !assert.Nil(t, manager.Upload(ctx, URL, file.DefaultFileOsMode, strings.NewReader(content)), useCase.description) {
+			continue
+		}
+		objects, err := manager.List(ctx, URL, useCase.listOptions...)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, 1, len(objects), useCase.description)
+		assert.EqualValues(t, useCase.expectOpens, delegate.opens, useCase.description)
+	}
+}
+
+//openCounter counts delegate OpenURL calls
+type openCounter struct {
+	storage.Manager
+	opens int
+}
+
+func (c *openCounter) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	c.opens++
+	return c.Manager.OpenURL(ctx, URL, options...)
+}
@@ -0,0 +1,185 @@
+package compress
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	//GzScheme represents gzip stream scheme, i.e. file:/tmp/app.log.gz/gz://localhost/
+	GzScheme = "gz"
+	//Bz2Scheme represents bzip2 stream scheme
+	Bz2Scheme = "bz2"
+	//XzScheme represents xz stream scheme, xz codec has to be registered
+	XzScheme = "xz"
+	//unknownSize represents decoded stream size that can not be recovered
+	unknownSize = -1
+)
+
+var schemeCodecs = map[string]string{GzScheme: Gzip, Bz2Scheme: Bzip2, XzScheme: Xz}
+
+//stream represents single file compressed stream manager, content is decoded from scheme extension URL
+type stream struct {
+	scheme string
+	source storage.Manager
+}
+
+func (s *stream) decorator(options []storage.Option) (*manager, error) {
+	source := s.source
+	if source == nil {
+		option.Assign(options, &source)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("manager for %v stream was empty", s.scheme)
+	}
+	return &manager{Manager: source, compression: option.NewCompression(schemeCodecs[s.scheme])}, nil
+}
+
+func (s *stream) sourceURL(URL string) (string, error) {
+	result := url.SchemeExtensionURL(URL)
+	if result == "" {
+		return "", fmt.Errorf("extended URL was empty: %v", URL)
+	}
+	return result, nil
+}
+
+//size returns decoded stream size, unknownSize if it can not be recovered from encoded content
+func (s *stream) size(ctx context.Context, decorator *manager, source storage.Object, options []storage.Option) int64 {
+	if !decorator.listSize(options) {
+		return unknownSize
+	}
+	codec, err := decorator.codec(source.URL(), nil)
+	if err != nil {
+		return unknownSize
+	}
+	sizer, ok := codec.(Sizer)
+	if !ok {
+		return unknownSize
+	}
+	if size, ok := decorator.size(ctx, source, sizer); ok {
+		return size
+	}
+	return unknownSize
+}
+
+//List returns decoded stream object, its size is unknown (-1) unless recovered from encoded content
+func (s *stream) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	decorator, err := s.decorator(options)
+	if err != nil {
+		return nil, err
+	}
+	sourceURL, err := s.sourceURL(URL)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := decorator.Manager.List(ctx, sourceURL)
+	if err != nil || len(objects) == 0 {
+		return objects, err
+	}
+	source := objects[0]
+	if source.IsDir() {
+		return nil, fmt.Errorf("%v: is directory", sourceURL)
+	}
+	info := file.NewInfo(streamName(source.Name()), s.size(ctx, decorator, source, options), source.Mode(), source.ModTime(), false)
+	return []storage.Object{object.New(URL, info, source)}, nil
+}
+
+//Open returns decoding reader
+func (s *stream) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
+	return s.OpenURL(ctx, object.URL(), options...)
+}
+
+//OpenURL returns decoding reader
+func (s *stream) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	decorator, err := s.decorator(options)
+	if err != nil {
+		return nil, err
+	}
+	sourceURL, err := s.sourceURL(URL)
+	if err != nil {
+		return nil, err
+	}
+	return decorator.OpenURL(ctx, sourceURL)
+}
+
+//Upload encodes content into scheme extension URL
+func (s *stream) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	decorator, err := s.decorator(options)
+	if err != nil {
+		return err
+	}
+	sourceURL, err := s.sourceURL(URL)
+	if err != nil {
+		return err
+	}
+	return decorator.Upload(ctx, sourceURL, mode, reader)
+}
+
+//Delete deletes scheme extension URL
+func (s *stream) Delete(ctx context.Context, URL string, options ...storage.Option) error {
+	decorator, err := s.decorator(options)
+	if err != nil {
+		return err
+	}
+	sourceURL, err := s.sourceURL(URL)
+	if err != nil {
+		return err
+	}
+	return decorator.Delete(ctx, sourceURL)
+}
+
+//Create creates empty stream
+func (s *stream) Create(ctx context.Context, URL string, mode os.FileMode, isDir bool, options ...storage.Option) error {
+	if isDir {
+		return fmt.Errorf("%v stream does not support directories", s.scheme)
+	}
+	return s.Upload(ctx, URL, mode, strings.NewReader(""), options...)
+}
+
+//Scheme returns stream scheme
+func (s *stream) Scheme() string {
+	return s.scheme
+}
+
+//Close closes manager
+func (s *stream) Close() error {
+	return nil
+}
+
+//streamName returns decoded stream name, i.e. app.log for app.log.gz or app.tar for app.tgz
+func streamName(name string) string {
+	ext := path.Ext(name)
+	if strings.ToLower(ext) == ".tgz" {
+		return strings.TrimSuffix(name, ext) + ".tar"
+	}
+	if Extension(name) != "" {
+		return strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+//NewStream creates compressed stream manager for gz, bz2 or xz scheme, source manager is taken from options
+func NewStream(scheme string, options ...storage.Option) storage.Manager {
+	var source storage.Manager
+	option.Assign(options, &source)
+	return &stream{scheme: scheme, source: source}
+}
+
+//StreamProvider returns compressed stream manager provider for supplied scheme
+func StreamProvider(scheme string) func(options ...storage.Option) (storage.Manager, error) {
+	return func(options ...storage.Option) (storage.Manager, error) {
+		if _, ok := schemeCodecs[scheme]; !ok {
+			return nil, fmt.Errorf("unsupported compressed stream scheme: %v", scheme)
+		}
+		return NewStream(scheme, options...), nil
+	}
+}
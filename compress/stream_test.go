@@ -0,0 +1,174 @@
+package compress_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseDir := path.Join(os.TempDir(), "afs_stream")
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	archive, err := newTarGz(map[string]string{"dir/file.txt": "file content", "dir/other.txt": "other content", "root.txt": "root"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, name := range []string{"a.tgz", "b.tar.gz"} {
+		if !assert.Nil(t, fs.Upload(ctx, path.Join(baseDir, name), file.DefaultFileOsMode, bytes.NewReader(archive))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		URL         string
+		upload      string
+		expect      string
+		expectList  []string
+		hasError    bool
+	}{
+		{
+			description: "gz stream upload and download",
+			URL:         fmt.Sprintf("file:%v/app.log.gz/gz://localhost/", baseDir),
+			upload:      "log content",
+			expect:      "log content",
+			expectList:  []string{"app.log"},
+		},
+		{
+			description: "nested gz and tar",
+			URL:         fmt.Sprintf("file:%v/a.tgz/gz://localhost/tar://localhost/dir/file.txt", baseDir),
+			expect:      "file content",
+		},
+		{
+			description: "nested gz and tar listing",
+			URL:         fmt.Sprintf("file:%v/a.tgz/gz://localhost/tar://localhost/dir", baseDir),
+			expectList:  []string{"dir", "file.txt", "other.txt"},
+		},
+		{
+			description: "gz stream name",
+			URL:         fmt.Sprintf("file:%v/a.tgz/gz://localhost", baseDir),
+			expectList:  []string{"a.tar"},
+		},
+		{
+			description: "tgz detection",
+			URL:         fmt.Sprintf("file:%v/a.tgz/tar://localhost/dir/other.txt", baseDir),
+			expect:      "other content",
+		},
+		{
+			description: "tar.gz upload",
+			URL:         fmt.Sprintf("file:%v/b.tar.gz/tar://localhost/new.txt", baseDir),
+			upload:      "new content",
+			expect:      "new content",
+		},
+		{
+			description: "bz2 upload",
+			URL:         fmt.Sprintf("file:%v/app.log.bz2/bz2://localhost/", baseDir),
+			upload:      "log content",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		if useCase.upload != "" {
+			err := fs.Upload(ctx, useCase.URL, file.DefaultFileOsMode, strings.NewReader(useCase.upload))
+			if useCase.hasError {
+				assert.NotNil(t, err, useCase.description)
+				continue
+			}
+			if !assert.Nil(t, err, useCase.description) {
+				continue
+			}
+		}
+		if useCase.expect != "" {
+			data, err := fs.DownloadWithURL(ctx, useCase.URL)
+			if assert.Nil(t, err, useCase.description) {
+				assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+			}
+		}
+		if len(useCase.expectList) > 0 {
+			objects, err := fs.List(ctx, useCase.URL)
+			if !assert.Nil(t, err, useCase.description) {
+				continue
+			}
+			var actual []string
+			for _, object := range objects {
+				actual = append(actual, object.Name())
+			}
+			assert.EqualValues(t, useCase.expectList, actual, useCase.description)
+		}
+	}
+
+	data, err := ioutil.ReadFile(path.Join(baseDir, "b.tar.gz"))
+	if assert.Nil(t, err) {
+		_, err = gzip.NewReader(bytes.NewReader(data))
+		assert.Nil(t, err, "rewritten tar.gz should stay compressed")
+	}
+	data, err = fs.DownloadWithURL(ctx, fmt.Sprintf("file:%v/b.tar.gz/tar://localhost/root.txt", baseDir))
+	assert.Nil(t, err)
+	assert.EqualValues(t, "root", string(data))
+}
+
+func TestStream_List_Size(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	baseDir := path.Join(os.TempDir(), "afs_stream_size")
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	content := strings.Repeat("log line\n", 100)
+
+	var useCases = []struct {
+		description string
+		URL         string
+		expect      int64
+	}{
+		{description: "file source size", URL: fmt.Sprintf("file:%v/app.log.gz/gz://localhost/", baseDir), expect: int64(len(content))},
+		{description: "remote source unknown size", URL: "mem:/stream_size/app.log.gz/gz://localhost/", expect: -1},
+	}
+	for _, useCase := range useCases {
+		if !assert.Nil(t, fs.Upload(ctx, useCase.URL, file.DefaultFileOsMode, strings.NewReader(content)), useCase.description) {
+			continue
+		}
+		objects, err := fs.List(ctx, useCase.URL)
+		if assert.Nil(t, err, useCase.description) && assert.EqualValues(t, 1, len(objects), useCase.description) {
+			assert.EqualValues(t, "app.log", objects[0].Name(), useCase.description)
+			assert.EqualValues(t, useCase.expect, objects[0].Size(), useCase.description)
+		}
+	}
+}
+
+func newTarGz(files map[string]string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	gzWriter := gzip.NewWriter(buffer)
+	writer := tar.NewWriter(gzWriter)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content := files[name]
+		if err := writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	err := gzWriter.Close()
+	return buffer.Bytes(), err
+}
@@ -3,6 +3,7 @@ package afs
 import (
 	"context"
 	"github.com/pkg/errors"
+	"github.com/viant/afs/delta"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
@@ -34,7 +35,7 @@ func (s *service) updateDestURL(sourceURL, destURL string) string {
 }
 
 func (s *service) copy(ctx context.Context, sourceURL, destURL string, srcOptions *option.Source, destOptions *option.Dest,
-	walker storage.Walker, uploader storage.BatchUploader) (err error) {
+	walker storage.Walker, uploader storage.BatchUploader, parallel *option.Parallel, deltaOption *option.Delta, tracker *transfer) (err error) {
 
 	source, err := s.Object(ctx, sourceURL, *srcOptions...)
 	if err != nil {
@@ -55,7 +56,14 @@ func (s *service) copy(ctx context.Context, sourceURL, destURL string, srcOption
 		destURL, mappedName = url.Split(destURL, file.Scheme)
 	}
 
-	if url.IsSchemeEquals(sourceURL, destURL) && modifier == nil && isInternalWalker {
+	var patcher delta.Patcher
+	if srv, ok := uploader.(*service); ok && srv == s && isInternalWalker && deltaOption != nil {
+		if patcher, err = s.deltaPatcher(ctx, destURL, *destOptions); err != nil {
+			return err
+		}
+	}
+	isParallel := parallel != nil && parallel.Workers > 1
+	if url.IsSchemeEquals(sourceURL, destURL) && modifier == nil && isInternalWalker && !isParallel && !tracker.isTracked() && patcher == nil { //native copy does not report transferred files
 		sourceManager, err := s.manager(ctx, sourceURL, *srcOptions)
 		if err != nil {
 			return err
@@ -70,6 +78,18 @@ func (s *service) copy(ctx context.Context, sourceURL, destURL string, srcOption
 		}
 	}
 
+	if isInternalWalker && tracker.progress != nil && tracker.progress.Total {
+		if err = s.total(ctx, sourceURL, *srcOptions, tracker); err != nil {
+			return err
+		}
+	}
+	if isInternalWalker && isParallel {
+		concurrentUpload, err := s.isConcurrentUploader(ctx, uploader, destURL, *destOptions)
+		if err != nil {
+			return err
+		}
+		*srcOptions = append(*srcOptions, option.NewParallel(parallel.Workers, concurrentUpload))
+	}
 	upload, closer, err := uploader.Uploader(ctx, destURL, *destOptions...)
 	if err != nil {
 		return err
@@ -82,6 +102,10 @@ func (s *service) copy(ctx context.Context, sourceURL, destURL string, srcOption
 	}()
 
 	err = walker.Walk(ctx, sourceURL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
+		objectURL := url.Join(baseURL, path.Join(parent, info.Name()))
+		if !info.IsDir() {
+			reader = tracker.reader(reader)
+		}
 		if mappedName != "" {
 			info = file.NewInfo(mappedName, info.Size(), info.Mode(), info.ModTime(), info.IsDir())
 		}
@@ -91,13 +115,55 @@ func (s *service) copy(ctx context.Context, sourceURL, destURL string, srcOption
 				return false, err
 			}
 		}
-		err = upload(ctx, parent, info, reader)
+		if patcher != nil && !info.IsDir() {
+			fileURL := url.Join(destURL, path.Join(parent, info.Name()))
+			if destObject, _ := s.Object(ctx, fileURL, *destOptions...); destObject != nil && !destObject.IsDir() {
+				if err = s.deltaUpload(ctx, reader, patcher, fileURL, deltaOption, *destOptions); err == nil {
+					tracker.done(objectURL)
+				}
+				return err == nil, err
+			}
+		}
+		if err = upload(ctx, parent, info, reader); err == nil && !info.IsDir() {
+			tracker.done(objectURL)
+		}
 		return err == nil, err
 	}, *srcOptions...)
 	return err
 
 }
 
+//total sets total files and bytes of source location
+func (s *service) total(ctx context.Context, sourceURL string, srcOptions option.Source, tracker *transfer) error {
+	listOptions := append([]storage.Option{option.NewRecursive(true)}, srcOptions...)
+	objects, err := s.List(ctx, sourceURL, listOptions...)
+	if err != nil {
+		return err
+	}
+	tracker.stats.TotalFiles, tracker.stats.TotalBytes = 0, 0
+	for _, object := range objects {
+		if object.IsDir() {
+			continue
+		}
+		tracker.stats.TotalFiles++
+		tracker.stats.TotalBytes += object.Size()
+	}
+	return nil
+}
+
+//isConcurrentUploader returns true if default service uploader uses destination manager without own batch uploader
+func (s *service) isConcurrentUploader(ctx context.Context, uploader storage.BatchUploader, destURL string, destOptions option.Dest) (bool, error) {
+	if srv, ok := uploader.(*service); !ok || srv != s {
+		return false, nil
+	}
+	manager, err := s.manager(ctx, destURL, destOptions)
+	if err != nil {
+		return false, err
+	}
+	_, ok := manager.(storage.BatchUploader)
+	return !ok, nil
+}
+
 func (s *service) Copy(ctx context.Context, sourceURL, destURL string, options ...storage.Option) (err error) {
 	sourceURL = url.Normalize(sourceURL, file.Scheme)
 	destURL = url.Normalize(destURL, file.Scheme)
@@ -106,9 +172,13 @@ func (s *service) Copy(ctx context.Context, sourceURL, destURL string, options .
 
 	var walker storage.Walker
 	var uploader storage.BatchUploader
+	var parallel *option.Parallel
+	var progress *option.Progress
+	var summary *option.Transfer
+	var deltaOption *option.Delta
 
 	match, modifier := option.GetWalkOptions(options)
-	option.Assign(options, &sourceOptions, &destOptions, &match, &walker, &uploader, &modifier)
+	option.Assign(options, &sourceOptions, &destOptions, &match, &walker, &uploader, &modifier, &parallel, &progress, &summary, &deltaOption)
 	if match != nil {
 		*sourceOptions = append(*sourceOptions, match)
 	}
@@ -125,5 +195,10 @@ func (s *service) Copy(ctx context.Context, sourceURL, destURL string, options .
 	if isInteralWalker {
 		destURL = s.updateDestURL(sourceURL, destURL)
 	}
-	return s.copy(ctx, sourceURL, destURL, sourceOptions, destOptions, walker, uploader)
+	tracker := newTransfer(progress, summary != nil)
+	err = s.copy(ctx, sourceURL, destURL, sourceOptions, destOptions, walker, uploader, parallel, deltaOption, tracker)
+	if summary != nil {
+		*summary = tracker.summary()
+	}
+	return err
 }
@@ -0,0 +1,137 @@
+package dav
+
+import (
+	"context"
+	"fmt"
+	ahttp "github.com/viant/afs/http"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+func (s *manager) getClient(baseURL string, options ...storage.Option) (*http.Client, error) {
+	baseURL, _ = url.Base(baseURL, Scheme)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	client, ok := s.baseURLClients[baseURL]
+	if ok {
+		return client, nil
+	}
+	if len(s.options) > 0 {
+		options = append(s.options, options...)
+	}
+	var clientProvider ahttp.ClientProvider
+	option.Assign(options, &clientProvider)
+	if clientProvider == nil {
+		if s.client == nil {
+			s.client = http.DefaultClient
+		}
+		return s.client, nil
+	}
+	var err error
+	if client, err = clientProvider(baseURL, options); err != nil {
+		return nil, err
+	}
+	s.baseURLClients[baseURL] = client
+	return client, nil
+}
+
+//httpURL converts dav/davs URL into http/https URL
+func httpURL(URL string) string {
+	scheme := url.Scheme(URL, Scheme)
+	_, URLPath := url.Base(URL, scheme)
+	httpScheme := ahttp.Scheme
+	if scheme == SecureScheme {
+		httpScheme = ahttp.SecureScheme
+	}
+	return httpScheme + "://" + url.Host(URL) + URLPath
+}
+
+//newRequest creates a http request for supplied dav URL
+func (s *manager) newRequest(ctx context.Context, method, URL string, body io.Reader, options []storage.Option) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, method, httpURL(URL), body)
+	if err != nil {
+		return nil, err
+	}
+	lock := &Lock{}
+	if _, ok := option.Assign(options, &lock); ok && lock.Token != "" {
+		request.Header.Set(ifHeader, "(<"+lock.Token+">)")
+	}
+	return request, nil
+}
+
+func (s *manager) run(ctx context.Context, URL string, request *http.Request, options ...storage.Option) (*http.Response, error) {
+	if len(s.options) > 0 {
+		options = append(s.options, options...)
+	}
+	var basicAuthProvider option.BasicAuth
+	header := http.Header{}
+	option.Assign(options, &basicAuthProvider, &header)
+	for k, v := range header {
+		request.Header[k] = v
+	}
+	if basicAuthProvider != nil {
+		username, password := basicAuthProvider.Credentials()
+		request.SetBasicAuth(username, password)
+	}
+	client, err := s.getClient(URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(request)
+}
+
+//do runs request and checks response status, it returns not found error for 404 status
+func (s *manager) do(ctx context.Context, method, URL string, body io.Reader, header http.Header, options []storage.Option) (*http.Response, error) {
+	request, err := s.newRequest(ctx, method, URL, body, options)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		request.Header[k] = v
+	}
+	response, err := s.run(ctx, URL, request, options...)
+	if err != nil {
+		return nil, err
+	}
+	if ahttp.IsStatusOK(response) {
+		return response, nil
+	}
+	closeResponse(response)
+	return nil, newStatusError(method, URL, response.StatusCode)
+}
+
+func closeResponse(response *http.Response) {
+	if response.Body != nil {
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		_ = response.Body.Close()
+	}
+}
+
+//StatusError represents unexpected http status error
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+//Error returns error message
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("failed to %v %v, invalid status code: %v", strings.ToLower(e.Method), e.URL, e.StatusCode)
+}
+
+func newStatusError(method, URL string, statusCode int) error {
+	return &StatusError{Method: method, URL: URL, StatusCode: statusCode}
+}
+
+//ErrorCode returns http status code for supplied error or zero
+func (s *manager) ErrorCode(err error) int {
+	if statusError, ok := err.(*StatusError); ok {
+		return statusError.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package dav
+
+import (
+	"context"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const methodMkcol = "MKCOL"
+
+//Create creates a collection with MKCOL or an empty resource with PUT
+func (s *manager) Create(ctx context.Context, URL string, mode os.FileMode, isDir bool, options ...storage.Option) error {
+	if isDir {
+		return s.mkcol(ctx, URL, options)
+	}
+	var reader io.Reader
+	options, _ = option.Assign(options, &reader)
+	if reader == nil {
+		reader = strings.NewReader("")
+	}
+	return s.Upload(ctx, URL, mode, reader, options...)
+}
+
+//mkcol creates collection with all missing parent collections
+func (s *manager) mkcol(ctx context.Context, URL string, options []storage.Option) error {
+	baseURL, URLPath := url.Base(URL, Scheme)
+	elements := strings.Split(strings.Trim(URLPath, "/"), "/")
+	collectionPath := ""
+	for _, element := range elements {
+		if element == "" {
+			continue
+		}
+		collectionPath += "/" + element
+		collectionURL := url.Join(baseURL, collectionPath)
+		if s.hasCollection(collectionURL) {
+			continue
+		}
+		response, err := s.do(ctx, methodMkcol, collectionURL, nil, nil, options)
+		if err == nil {
+			closeResponse(response)
+		} else if code := s.ErrorCode(err); code != http.StatusMethodNotAllowed {
+			return err
+		}
+		s.setCollection(collectionURL, true)
+	}
+	return nil
+}
+
+func (s *manager) hasCollection(URL string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.collections[URL]
+}
+
+func (s *manager) setCollection(URL string, exists bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if exists {
+		s.collections[URL] = true
+		return
+	}
+	for candidate := range s.collections {
+		if candidate == URL || strings.HasPrefix(candidate, URL+"/") {
+			delete(s.collections, candidate)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package dav
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"testing"
+)
+
+func TestManager_Create(t *testing.T) {
+	baseURL := startServer(t)
+	ctx := context.Background()
+	manager := newManager()
+
+	var useCases = []struct {
+		description string
+		location    string
+		isDir       bool
+	}{
+		{
+			description: "nested collection",
+			location:    "a/b/c",
+			isDir:       true,
+		},
+		{
+			description: "existing collection",
+			location:    "a/b",
+			isDir:       true,
+		},
+		{
+			description: "empty resource",
+			location:    "a/x/empty.txt",
+		},
+	}
+
+	for _, useCase := range useCases {
+		URL := url.Join(baseURL, useCase.location)
+		err := manager.Create(ctx, URL, file.DefaultDirOsMode, useCase.isDir)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		object, err := manager.Object(ctx, URL)
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, useCase.isDir, object.IsDir(), useCase.description)
+		}
+	}
+	err := manager.Delete(ctx, url.Join(baseURL, "a"))
+	assert.Nil(t, err)
+	err = manager.Create(ctx, url.Join(baseURL, "a/b/c"), file.DefaultDirOsMode, true)
+	assert.Nil(t, err, "recreate after delete")
+}
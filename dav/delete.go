@@ -0,0 +1,18 @@
+package dav
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"net/http"
+)
+
+//Delete sends delete method with supplied URL
+func (s *manager) Delete(ctx context.Context, URL string, options ...storage.Option) error {
+	response, err := s.do(ctx, http.MethodDelete, URL, nil, nil, options)
+	if err != nil {
+		return err
+	}
+	closeResponse(response)
+	s.setCollection(URL, false)
+	return nil
+}
@@ -0,0 +1,2 @@
+//Package dav implements WebDAV based storage manager
+package dav
@@ -0,0 +1,67 @@
+package dav
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"net/http"
+	"strings"
+)
+
+//List lists collection members or returns a single resource object
+func (s *manager) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	resources, err := s.propfind(ctx, URL, "1", options)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, URLPath := url.Base(URL, Scheme)
+	self := strings.Trim(URLPath, "/")
+	match, page := option.GetListOptions(options)
+	var result = make([]storage.Object, 0, len(resources))
+	for _, resource := range resources {
+		if strings.Trim(resource.URLPath, "/") == self {
+			result = append([]storage.Object{resource.object(baseURL)}, result...)
+			continue
+		}
+		object := resource.object(baseURL)
+		if !match(URLPath, object) {
+			continue
+		}
+		page.Increment()
+		if page.ShallSkip() {
+			continue
+		}
+		result = append(result, object)
+		if page.HasReachedLimit() {
+			break
+		}
+	}
+	return result, nil
+}
+
+//Object returns a storage object for supplied URL
+func (s *manager) Object(ctx context.Context, URL string, options ...storage.Option) (storage.Object, error) {
+	resources, err := s.propfind(ctx, URL, "0", options)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("%v: not found", URL)
+	}
+	baseURL, _ := url.Base(URL, Scheme)
+	return resources[0].object(baseURL), nil
+}
+
+//Exists checks if resource exists
+func (s *manager) Exists(ctx context.Context, URL string, options ...storage.Option) (bool, error) {
+	_, err := s.propfind(ctx, URL, "0", options)
+	if err == nil {
+		return true, nil
+	}
+	if s.ErrorCode(err) == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
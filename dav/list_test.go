@@ -0,0 +1,75 @@
+package dav
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/url"
+	"strings"
+	"testing"
+)
+
+func TestManager_List(t *testing.T) {
+	baseURL := startServer(t)
+	ctx := context.Background()
+	manager := newManager()
+	for _, location := range []string{"folder/asset1.txt", "folder/asset2.txt", "folder/sub/asset3.txt"} {
+		err := manager.Upload(ctx, url.Join(baseURL, location), 0644, strings.NewReader("test:"+location))
+		if !assert.Nil(t, err, location) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		URL         string
+		expect      map[string]bool
+		expectSize  int64
+	}{
+		{
+			description: "collection listing",
+			URL:         url.Join(baseURL, "folder"),
+			expect: map[string]bool{
+				"folder":     true,
+				"asset1.txt": false,
+				"asset2.txt": false,
+				"sub":        true,
+			},
+		},
+		{
+			description: "resource listing",
+			URL:         url.Join(baseURL, "folder/sub/asset3.txt"),
+			expect: map[string]bool{
+				"asset3.txt": false,
+			},
+			expectSize: int64(len("test:folder/sub/asset3.txt")),
+		},
+	}
+
+	for _, useCase := range useCases {
+		objects, err := manager.List(ctx, useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(useCase.expect), len(objects), useCase.description)
+		assert.True(t, url.Equals(useCase.URL, objects[0].URL()), useCase.description)
+		for _, object := range objects {
+			isDir, ok := useCase.expect[object.Name()]
+			if assert.True(t, ok, useCase.description+" "+object.Name()) {
+				assert.EqualValues(t, isDir, object.IsDir(), useCase.description+" "+object.Name())
+			}
+		}
+		if useCase.expectSize > 0 {
+			assert.EqualValues(t, useCase.expectSize, objects[0].Size(), useCase.description)
+		}
+		object, err := manager.Object(ctx, useCase.URL)
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, objects[0].Name(), object.Name(), useCase.description)
+		}
+		has, err := manager.Exists(ctx, useCase.URL)
+		assert.Nil(t, err, useCase.description)
+		assert.True(t, has, useCase.description)
+	}
+	has, err := manager.Exists(ctx, url.Join(baseURL, "missing.txt"))
+	assert.Nil(t, err)
+	assert.False(t, has)
+}
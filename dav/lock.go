@@ -0,0 +1,78 @@
+package dav
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/storage"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	methodLock      = "LOCK"
+	methodUnlock    = "UNLOCK"
+	lockTokenHeader = "Lock-Token"
+	timeoutHeader   = "Timeout"
+	ifHeader        = "If"
+	lockBody        = `<?xml version="1.0" encoding="utf-8"?>` +
+		`<D:lockinfo xmlns:D="DAV:"><D:lockscope><D:exclusive/></D:lockscope>` +
+		`<D:locktype><D:write/></D:locktype><D:owner>%v</D:owner></D:lockinfo>`
+	defaultLockOwner = "afs"
+)
+
+//Lock represents lock token option, modifying requests with this option submit the token with If header
+type Lock struct {
+	Token string
+}
+
+//NewLock creates a lock token option
+func NewLock(token string) *Lock {
+	return &Lock{Token: token}
+}
+
+//Locker represents WebDAV resource locker
+type Locker interface {
+	//Lock acquires exclusive write lock for supplied URL, zero timeout requests infinite lock
+	Lock(ctx context.Context, URL string, timeout time.Duration, options ...storage.Option) (*Lock, error)
+	//Unlock releases supplied lock
+	Unlock(ctx context.Context, URL string, lock *Lock, options ...storage.Option) error
+}
+
+//Lock acquires exclusive write lock for supplied URL
+func (s *manager) Lock(ctx context.Context, URL string, timeout time.Duration, options ...storage.Option) (*Lock, error) {
+	header := http.Header{}
+	header.Set(depthHeader, "infinity")
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	if timeout > 0 {
+		header.Set(timeoutHeader, fmt.Sprintf("Second-%d", int(timeout.Seconds())))
+	} else {
+		header.Set(timeoutHeader, "Infinite")
+	}
+	body := fmt.Sprintf(lockBody, defaultLockOwner)
+	response, err := s.do(ctx, methodLock, URL, strings.NewReader(body), header, options)
+	if err != nil {
+		return nil, err
+	}
+	closeResponse(response)
+	token := strings.Trim(response.Header.Get(lockTokenHeader), "<>")
+	if token == "" {
+		return nil, fmt.Errorf("failed to lock %v, %v header was empty", URL, lockTokenHeader)
+	}
+	return NewLock(token), nil
+}
+
+//Unlock releases supplied lock
+func (s *manager) Unlock(ctx context.Context, URL string, lock *Lock, options ...storage.Option) error {
+	if lock == nil || lock.Token == "" {
+		return fmt.Errorf("lock token was empty: %v", URL)
+	}
+	header := http.Header{}
+	header.Set(lockTokenHeader, "<"+lock.Token+">")
+	response, err := s.do(ctx, methodUnlock, URL, nil, header, options)
+	if err != nil {
+		return err
+	}
+	closeResponse(response)
+	return nil
+}
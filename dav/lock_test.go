@@ -0,0 +1,38 @@
+package dav
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/url"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestManager_Lock(t *testing.T) {
+	baseURL := startServer(t)
+	ctx := context.Background()
+	manager := newManager()
+	URL := url.Join(baseURL, "locked/asset.txt")
+	err := manager.Upload(ctx, URL, 0644, strings.NewReader("abc"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var locker Locker = manager
+	lock, err := locker.Lock(ctx, URL, time.Minute)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotEmpty(t, lock.Token)
+
+	err = manager.Upload(ctx, URL, 0644, strings.NewReader("xyz"))
+	assert.EqualValues(t, http.StatusLocked, manager.ErrorCode(err), "upload without lock token")
+	err = manager.Upload(ctx, URL, 0644, strings.NewReader("xyz"), lock)
+	assert.Nil(t, err, "upload with lock token")
+
+	err = locker.Unlock(ctx, URL, lock)
+	assert.Nil(t, err)
+	err = manager.Delete(ctx, URL)
+	assert.Nil(t, err)
+}
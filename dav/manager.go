@@ -0,0 +1,44 @@
+package dav
+
+import (
+	"github.com/viant/afs/storage"
+	"net/http"
+	"sync"
+)
+
+type manager struct {
+	client         *http.Client
+	mux            sync.Mutex
+	baseURLClients map[string]*http.Client
+	collections    map[string]bool
+	options        []storage.Option
+}
+
+//Close closes mananger
+func (s *manager) Close() error {
+	if s.client != nil {
+		s.client.CloseIdleConnections()
+	}
+	for _, client := range s.baseURLClients {
+		client.CloseIdleConnections()
+	}
+	return nil
+}
+
+//Scheme returns schmea
+func (s *manager) Scheme() string {
+	return Scheme
+}
+
+func newManager(options ...storage.Option) *manager {
+	return &manager{
+		options:        options,
+		baseURLClients: make(map[string]*http.Client),
+		collections:    make(map[string]bool),
+	}
+}
+
+//New creates WebDAV manager
+func New(options ...storage.Option) storage.Manager {
+	return newManager(options...)
+}
@@ -0,0 +1,46 @@
+package dav
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"net/http"
+)
+
+const (
+	methodMove        = "MOVE"
+	methodCopy        = "COPY"
+	destinationHeader = "Destination"
+	overwriteHeader   = "Overwrite"
+)
+
+//Move moves source to dest with MOVE method
+func (s *manager) Move(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error {
+	if err := s.transfer(ctx, methodMove, sourceURL, destURL, options); err != nil {
+		return err
+	}
+	s.setCollection(sourceURL, false)
+	return nil
+}
+
+//Copy copies source to dest with COPY method
+func (s *manager) Copy(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error {
+	return s.transfer(ctx, methodCopy, sourceURL, destURL, options)
+}
+
+func (s *manager) transfer(ctx context.Context, method, sourceURL, destURL string, options []storage.Option) error {
+	parentURL, _ := url.Split(destURL, Scheme)
+	if err := s.mkcol(ctx, parentURL, options); err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set(destinationHeader, httpURL(destURL))
+	header.Set(overwriteHeader, "T")
+	header.Set(depthHeader, "infinity")
+	response, err := s.do(ctx, method, sourceURL, nil, header, options)
+	if err != nil {
+		return err
+	}
+	closeResponse(response)
+	return nil
+}
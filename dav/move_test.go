@@ -0,0 +1,66 @@
+package dav
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/url"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestManager_Move(t *testing.T) {
+	baseURL := startServer(t)
+	ctx := context.Background()
+	manager := newManager()
+
+	var useCases = []struct {
+		description string
+		source      string
+		dest        string
+		copy        bool
+	}{
+		{
+			description: "move resource",
+			source:      "src/asset1.txt",
+			dest:        "dst/moved/asset1.txt",
+		},
+		{
+			description: "copy resource",
+			source:      "src/asset2.txt",
+			dest:        "dst/copied/asset2.txt",
+			copy:        true,
+		},
+		{
+			description: "copy collection",
+			source:      "src/sub/asset3.txt",
+			dest:        "dst/sub/asset3.txt",
+			copy:        true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		sourceURL := url.Join(baseURL, useCase.source)
+		destURL := url.Join(baseURL, useCase.dest)
+		err := manager.Upload(ctx, sourceURL, 0644, strings.NewReader(useCase.description))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.copy {
+			err = manager.Copy(ctx, sourceURL, destURL)
+		} else {
+			err = manager.Move(ctx, sourceURL, destURL)
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := manager.OpenURL(ctx, destURL)
+		if assert.Nil(t, err, useCase.description) {
+			data, _ := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.EqualValues(t, useCase.description, string(data), useCase.description)
+		}
+		has, _ := manager.Exists(ctx, sourceURL)
+		assert.EqualValues(t, useCase.copy, has, useCase.description)
+	}
+}
@@ -0,0 +1,22 @@
+package dav
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"io"
+	"net/http"
+)
+
+//Open downloads content for supplied object
+func (s *manager) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
+	return s.OpenURL(ctx, object.URL(), options...)
+}
+
+//OpenURL downloads content for supplied URL
+func (s *manager) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	response, err := s.do(ctx, http.MethodGet, URL, nil, nil, options)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
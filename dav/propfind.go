@@ -0,0 +1,126 @@
+package dav
+
+import (
+	"context"
+	"encoding/xml"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	methodPropfind = "PROPFIND"
+	depthHeader    = "Depth"
+	propfindBody   = `<?xml version="1.0" encoding="utf-8"?>` +
+		`<D:propfind xmlns:D="DAV:"><D:prop>` +
+		`<D:resourcetype/><D:getcontentlength/><D:getlastmodified/><D:getetag/><D:getcontenttype/>` +
+		`</D:prop></D:propfind>`
+)
+
+type multistatus struct {
+	XMLName   xml.Name   `xml:"DAV: multistatus"`
+	Responses []response `xml:"DAV: response"`
+}
+
+type response struct {
+	Href     string     `xml:"DAV: href"`
+	Propstat []propstat `xml:"DAV: propstat"`
+}
+
+type propstat struct {
+	Prop   prop   `xml:"DAV: prop"`
+	Status string `xml:"DAV: status"`
+}
+
+type prop struct {
+	ResourceType  resourceType `xml:"DAV: resourcetype"`
+	ContentLength string       `xml:"DAV: getcontentlength"`
+	LastModified  string       `xml:"DAV: getlastmodified"`
+	ETag          string       `xml:"DAV: getetag"`
+	ContentType   string       `xml:"DAV: getcontenttype"`
+}
+
+type resourceType struct {
+	Collection *struct{} `xml:"DAV: collection"`
+}
+
+//Resource represents WebDAV resource properties
+type Resource struct {
+	URLPath     string
+	IsDir       bool
+	Size        int64
+	Modified    time.Time
+	ETag        string
+	ContentType string
+}
+
+func (r *response) resource() (*Resource, error) {
+	href, err := neturl.Parse(r.Href)
+	if err != nil {
+		return nil, err
+	}
+	result := &Resource{URLPath: href.Path}
+	for _, stat := range r.Propstat {
+		if !strings.Contains(stat.Status, " 200 ") {
+			continue
+		}
+		result.IsDir = result.IsDir || stat.Prop.ResourceType.Collection != nil
+		if stat.Prop.ContentLength != "" {
+			result.Size, _ = strconv.ParseInt(stat.Prop.ContentLength, 10, 64)
+		}
+		if stat.Prop.LastModified != "" {
+			result.Modified, _ = http.ParseTime(stat.Prop.LastModified)
+		}
+		if stat.Prop.ETag != "" {
+			result.ETag = stat.Prop.ETag
+		}
+		if stat.Prop.ContentType != "" {
+			result.ContentType = stat.Prop.ContentType
+		}
+	}
+	return result, nil
+}
+
+//object converts resource into storage object
+func (r *Resource) object(baseURL string) storage.Object {
+	URLPath := strings.TrimRight(r.URLPath, "/")
+	_, name := path.Split(URLPath)
+	mode := file.DefaultFileOsMode
+	if r.IsDir {
+		mode = file.DefaultDirOsMode
+	}
+	info := file.NewInfo(name, r.Size, mode, r.Modified, r.IsDir)
+	return object.New(url.Join(baseURL, URLPath), info, r)
+}
+
+//propfind returns resources for supplied URL and depth
+func (s *manager) propfind(ctx context.Context, URL string, depth string, options []storage.Option) ([]*Resource, error) {
+	header := http.Header{}
+	header.Set(depthHeader, depth)
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+	response, err := s.do(ctx, methodPropfind, URL, strings.NewReader(propfindBody), header, options)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(response)
+	status := &multistatus{}
+	if err = xml.NewDecoder(response.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	var result = make([]*Resource, 0, len(status.Responses))
+	for i := range status.Responses {
+		resource, err := status.Responses[i].resource()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, resource)
+	}
+	return result, nil
+}
@@ -0,0 +1,10 @@
+package dav
+
+import (
+	"github.com/viant/afs/storage"
+)
+
+//Provider returns a WebDAV manager
+func Provider(options ...storage.Option) (storage.Manager, error) {
+	return New(options...), nil
+}
@@ -0,0 +1,7 @@
+package dav
+
+//Scheme represents WebDAV over http url scheme
+const Scheme = "dav"
+
+//SecureScheme represents WebDAV over https url scheme
+const SecureScheme = "davs"
@@ -0,0 +1,19 @@
+package dav
+
+import (
+	"golang.org/x/net/webdav"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+//startServer starts in memory WebDAV server, it returns server dav base URL
+func startServer(t *testing.T) string {
+	handler := &webdav.Handler{
+		FileSystem: webdav.NewMemFS(),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return strings.Replace(server.URL, "http://", Scheme+"://", 1)
+}
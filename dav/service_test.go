@@ -0,0 +1,102 @@
+package dav_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/asset"
+	"github.com/viant/afs/dav"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"golang.org/x/net/webdav"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestService_Copy(t *testing.T) {
+	server := httptest.NewServer(&webdav.Handler{FileSystem: webdav.NewMemFS(), LockSystem: webdav.NewMemLS()})
+	defer server.Close()
+	baseURL := strings.Replace(server.URL, "http://", dav.Scheme+"://", 1)
+	ctx := context.Background()
+	fs := afs.New()
+	defer fs.CloseAll()
+
+	assets := []*asset.Resource{
+		asset.NewFile("asset1.txt", []byte("test 1"), 0644),
+		asset.NewDir("folder1", 0755),
+		asset.NewFile("folder1/asset2.txt", []byte("test 2"), 0644),
+		asset.NewFile("folder1/sub/asset3.txt", []byte("test 3"), 0644),
+	}
+	memURL := "mem://localhost/dav/src"
+	_ = asset.Cleanup(mem.Singleton(), memURL)
+	if !assert.Nil(t, asset.Create(mem.Singleton(), memURL, assets)) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		source      string
+		dest        string
+		summary     *option.Transfer
+	}{
+		{
+			description: "mem to dav",
+			source:      memURL,
+			dest:        url.Join(baseURL, "data"),
+		},
+		{
+			description: "dav to dav",
+			source:      url.Join(baseURL, "data"),
+			dest:        url.Join(baseURL, "copy"),
+		},
+		{
+			description: "dav to dav with summary",
+			source:      url.Join(baseURL, "data"),
+			dest:        url.Join(baseURL, "tracked"),
+			summary:     option.NewTransfer(),
+		},
+		{
+			description: "dav to mem",
+			source:      url.Join(baseURL, "copy"),
+			dest:        "mem://localhost/dav/dst",
+		},
+	}
+
+	for _, useCase := range useCases {
+		var options []storage.Option
+		if useCase.summary != nil {
+			options = append(options, useCase.summary)
+		}
+		err := fs.Copy(ctx, useCase.source, useCase.dest, options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.summary != nil {
+			assert.EqualValues(t, 3, useCase.summary.Files, useCase.description)
+		}
+		actual := map[string]string{}
+		err = fs.Walk(ctx, useCase.dest, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+			if info.IsDir() {
+				return true, nil
+			}
+			data, err := io.ReadAll(reader)
+			actual[path.Join(parent, info.Name())] = string(data)
+			return true, err
+		})
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		for _, expect := range assets {
+			if expect.Dir {
+				continue
+			}
+			assert.EqualValues(t, string(expect.Data), actual[expect.Name], useCase.description+" "+expect.Name)
+		}
+	}
+}
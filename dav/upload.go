@@ -0,0 +1,24 @@
+package dav
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"net/http"
+	"os"
+)
+
+//Upload sends put request to supplied URL, missing parent collections are created
+func (s *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	parentURL, _ := url.Split(URL, Scheme)
+	if err := s.mkcol(ctx, parentURL, options); err != nil {
+		return err
+	}
+	response, err := s.do(ctx, http.MethodPut, URL, reader, nil, options)
+	if err != nil {
+		return err
+	}
+	closeResponse(response)
+	return nil
+}
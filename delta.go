@@ -0,0 +1,44 @@
+package afs
+
+import (
+	"context"
+	"github.com/viant/afs/delta"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+//deltaPatcher returns destination manager delta.Patcher, nil if delta can not be applied in place and destination files are uploaded as a whole
+func (s *service) deltaPatcher(ctx context.Context, destURL string, destOptions []storage.Option) (delta.Patcher, error) {
+	manager, err := s.manager(ctx, destURL, destOptions)
+	if err != nil {
+		return nil, err
+	}
+	patcher, _ := manager.(delta.Patcher)
+	return patcher, nil
+}
+
+//deltaUpload updates existing destination file with reader content, only blocks missing in destination copy are taken from reader,
+//destination signature is computed from patcher base copy
+func (s *service) deltaUpload(ctx context.Context, reader io.Reader, patcher delta.Patcher, destURL string, deltaOption *option.Delta, destOptions []storage.Option) error {
+	blockSize := deltaOption.BlockSize
+	if blockSize == 0 {
+		blockSize = delta.DefaultBlockSize
+	}
+	return patcher.Patch(ctx, destURL, func(base io.ReaderAt, writer io.Writer) error {
+		signature, err := delta.NewSignature(io.NewSectionReader(base, 0, math.MaxInt64), blockSize)
+		if err != nil {
+			return err
+		}
+		return delta.Compute(signature, reader, func(operation *delta.Operation) error {
+			if operation.IsLiteral() {
+				atomic.AddInt64(&deltaOption.Literal, int64(len(operation.Data)))
+			} else {
+				atomic.AddInt64(&deltaOption.Matched, int64(operation.Length))
+			}
+			return delta.Apply(base, operation, writer)
+		})
+	}, destOptions...)
+}
@@ -0,0 +1,42 @@
+package delta
+
+const adlerModulo = 65521
+
+//rolling represents rolling Adler-32 checksum over a fixed size window
+type rolling struct {
+	a, b   uint32
+	length uint32
+}
+
+//reset computes checksum of supplied window
+func (r *rolling) reset(window []byte) {
+	r.a, r.b = 1, 0
+	r.length = uint32(len(window))
+	for _, c := range window {
+		r.a = (r.a + uint32(c)) % adlerModulo
+		r.b = (r.b + r.a) % adlerModulo
+	}
+}
+
+//roll removes out byte from window start and appends in byte
+func (r *rolling) roll(out, in byte) {
+	r.a = (r.a + adlerModulo - uint32(out) + uint32(in)) % adlerModulo
+	r.b = (r.b + adlerModulo - (r.length*uint32(out))%adlerModulo + r.a + adlerModulo - 1) % adlerModulo
+}
+
+//shift removes out byte from window start
+func (r *rolling) shift(out byte) {
+	r.a = (r.a + adlerModulo - uint32(out)) % adlerModulo
+	r.b = (r.b + 2*adlerModulo - (r.length*uint32(out))%adlerModulo - 1) % adlerModulo
+	r.length--
+}
+
+func (r *rolling) sum() uint32 {
+	return r.b<<16 | r.a
+}
+
+func weakSum(window []byte) uint32 {
+	r := &rolling{}
+	r.reset(window)
+	return r.sum()
+}
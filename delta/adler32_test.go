@@ -0,0 +1,42 @@
+package delta
+
+import (
+	"github.com/stretchr/testify/assert"
+	"hash/adler32"
+	"math/rand"
+	"testing"
+)
+
+func TestRolling(t *testing.T) {
+	data := make([]byte, 4096)
+	rand.New(rand.NewSource(1)).Read(data)
+	for i := 0; i < 512; i++ {
+		data[i] = 0xff
+	}
+	var useCases = []struct {
+		description string
+		window      int
+	}{
+		{description: "small window", window: 3},
+		{description: "block window", window: 1024},
+		{description: "large window", window: 4000},
+	}
+	for _, useCase := range useCases {
+		checksum := &rolling{}
+		checksum.reset(data[:useCase.window])
+		assert.EqualValues(t, adler32.Checksum(data[:useCase.window]), checksum.sum(), useCase.description)
+		for i := 1; i+useCase.window <= len(data); i++ {
+			checksum.roll(data[i-1], data[i+useCase.window-1])
+			if !assert.EqualValues(t, adler32.Checksum(data[i:i+useCase.window]), checksum.sum(), useCase.description) {
+				break
+			}
+		}
+		start := len(data) - useCase.window
+		for i := start + 1; i < len(data); i++ {
+			checksum.shift(data[i-1])
+			if !assert.EqualValues(t, adler32.Checksum(data[i:]), checksum.sum(), useCase.description) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package delta
+
+import (
+	"io"
+)
+
+//maxLiteralSize represents max size of literal operation data
+const maxLiteralSize = 64 * 1024
+
+//Operation represents delta operation, it either copies Length bytes at Offset of destination copy or writes literal Data
+type Operation struct {
+	Offset int64
+	Length int
+	Data   []byte
+}
+
+//IsLiteral returns true if operation carries literal data
+func (o *Operation) IsLiteral() bool {
+	return o.Data != nil
+}
+
+//computer represents delta computation state, buffer holds pending literal (from literal to position) and current window
+type computer struct {
+	signature *Signature
+	reader    io.Reader
+	handler   func(operation *Operation) error
+	buffer    []byte
+	literal   int
+	position  int
+	eof       bool
+	checksum  rolling
+}
+
+//fill reads source until buffer holds full window or source is exhausted, pending literal is moved to buffer start
+func (c *computer) fill() error {
+	if c.eof || len(c.buffer)-c.position >= c.signature.BlockSize {
+		return nil
+	}
+	if c.literal > 0 {
+		n := copy(c.buffer, c.buffer[c.literal:])
+		c.buffer = c.buffer[:n]
+		c.position -= c.literal
+		c.literal = 0
+	}
+	for len(c.buffer)-c.position < c.signature.BlockSize {
+		n, err := c.reader.Read(c.buffer[len(c.buffer):cap(c.buffer)])
+		c.buffer = c.buffer[:len(c.buffer)+n]
+		if err == io.EOF {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *computer) window() []byte {
+	end := c.position + c.signature.BlockSize
+	if end > len(c.buffer) {
+		end = len(c.buffer)
+	}
+	return c.buffer[c.position:end]
+}
+
+func (c *computer) flush() error {
+	if c.literal == c.position {
+		return nil
+	}
+	data := make([]byte, c.position-c.literal)
+	copy(data, c.buffer[c.literal:c.position])
+	c.literal = c.position
+	return c.handler(&Operation{Data: data})
+}
+
+func (c *computer) compute() error {
+	if err := c.fill(); err != nil {
+		return err
+	}
+	c.checksum.reset(c.window())
+	for c.position < len(c.buffer) {
+		window := c.window()
+		if block := c.signature.lookup(c.checksum.sum(), window); block != nil {
+			if err := c.flush(); err != nil {
+				return err
+			}
+			if err := c.handler(&Operation{Offset: block.Offset, Length: block.Length}); err != nil {
+				return err
+			}
+			c.position += len(window)
+			c.literal = c.position
+			if err := c.fill(); err != nil {
+				return err
+			}
+			c.checksum.reset(c.window())
+			continue
+		}
+		out := c.buffer[c.position]
+		c.position++
+		if c.position-c.literal >= maxLiteralSize {
+			if err := c.flush(); err != nil {
+				return err
+			}
+		}
+		if err := c.fill(); err != nil {
+			return err
+		}
+		if c.position+c.signature.BlockSize <= len(c.buffer) {
+			c.checksum.roll(out, c.buffer[c.position+c.signature.BlockSize-1])
+		} else {
+			//source tail shorter than block size can only match the last destination block
+			c.checksum.shift(out)
+		}
+	}
+	return c.flush()
+}
+
+//Compute reads source content and emits operations rebuilding it from destination copy with supplied signature
+func Compute(signature *Signature, reader io.Reader, handler func(operation *Operation) error) error {
+	c := &computer{
+		signature: signature,
+		reader:    reader,
+		handler:   handler,
+		buffer:    make([]byte, 0, maxLiteralSize+2*signature.BlockSize),
+	}
+	return c.compute()
+}
+
+//Apply writes operation content using destination copy as base
+func Apply(base io.ReaderAt, operation *Operation, writer io.Writer) error {
+	if operation.IsLiteral() {
+		_, err := writer.Write(operation.Data)
+		return err
+	}
+	_, err := io.Copy(writer, io.NewSectionReader(base, operation.Offset, int64(operation.Length)))
+	return err
+}
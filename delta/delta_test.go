@@ -0,0 +1,99 @@
+package delta
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	base := make([]byte, 300*1024+123)
+	random.Read(base)
+	modified := func(fn func(data []byte) []byte) []byte {
+		return fn(append([]byte{}, base...))
+	}
+
+	var useCases = []struct {
+		description   string
+		dest          []byte
+		source        []byte
+		blockSize     int
+		maxLiteralLen int
+	}{
+		{
+			description:   "unchanged",
+			dest:          base,
+			source:        base,
+			blockSize:     1024,
+			maxLiteralLen: 0,
+		},
+		{
+			description: "changed in the middle",
+			dest:        base,
+			source: modified(func(data []byte) []byte {
+				copy(data[100000:], []byte("changed content"))
+				return data
+			}),
+			blockSize:     1024,
+			maxLiteralLen: 1024,
+		},
+		{
+			description: "inserted data",
+			dest:        base,
+			source: modified(func(data []byte) []byte {
+				return append(data[:5000], append([]byte("inserted content"), data[5000:]...)...)
+			}),
+			blockSize:     1024,
+			maxLiteralLen: 1024 + 16,
+		},
+		{
+			description: "appended and truncated head",
+			dest:        base,
+			source: modified(func(data []byte) []byte {
+				return append(data[777:], []byte("tail")...)
+			}),
+			blockSize:     2048,
+			maxLiteralLen: 2048 + 4,
+		},
+		{
+			description:   "empty destination",
+			dest:          []byte{},
+			source:        base,
+			blockSize:     1024,
+			maxLiteralLen: len(base),
+		},
+		{
+			description:   "empty source",
+			dest:          base,
+			source:        []byte{},
+			blockSize:     1024,
+			maxLiteralLen: 0,
+		},
+	}
+
+	for _, useCase := range useCases {
+		signature, err := NewSignature(bytes.NewReader(useCase.dest), useCase.blockSize)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(useCase.dest), signature.Size, useCase.description)
+		output := new(bytes.Buffer)
+		literalLen := 0
+		err = Compute(signature, bytes.NewReader(useCase.source), func(operation *Operation) error {
+			if operation.IsLiteral() {
+				literalLen += len(operation.Data)
+			}
+			return Apply(bytes.NewReader(useCase.dest), operation, output)
+		})
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.True(t, bytes.Equal(useCase.source, output.Bytes()), useCase.description)
+		assert.True(t, literalLen <= useCase.maxLiteralLen, useCase.description, literalLen)
+	}
+
+	_, err := NewSignature(bytes.NewReader(base), 0)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,3 @@
+//Package delta implements rsync style delta transfer: signature of a destination copy (rolling Adler-32 and MD5 per block),
+//delta operations computed from source content against the signature, and delta application on top of the destination copy
+package delta
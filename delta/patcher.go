@@ -0,0 +1,12 @@
+package delta
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"io"
+)
+
+//Patcher represents a manager rewriting stored content in place, patch writes new content using stored copy as base
+type Patcher interface {
+	Patch(ctx context.Context, URL string, patch func(base io.ReaderAt, writer io.Writer) error, options ...storage.Option) error
+}
@@ -0,0 +1,85 @@
+package delta
+
+import (
+	"crypto/md5"
+	"fmt"
+	"io"
+)
+
+//DefaultBlockSize represents default signature block size
+const DefaultBlockSize = 8 * 1024
+
+//Block represents signature block
+type Block struct {
+	Offset int64
+	Length int
+	Weak   uint32
+	Strong [md5.Size]byte
+}
+
+//Signature represents destination content signature
+type Signature struct {
+	BlockSize int
+	Size      int64
+	Blocks    []*Block
+	index     map[uint32][]*Block
+}
+
+//lookup returns block matching window
+func (s *Signature) lookup(weak uint32, window []byte) *Block {
+	candidates, ok := s.index[weak]
+	if !ok {
+		return nil
+	}
+	var strong [md5.Size]byte
+	computed := false
+	for _, candidate := range candidates {
+		if candidate.Length != len(window) {
+			continue
+		}
+		if !computed {
+			strong = md5.Sum(window)
+			computed = true
+		}
+		if candidate.Strong == strong {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func (s *Signature) init() {
+	s.index = make(map[uint32][]*Block)
+	for _, block := range s.Blocks {
+		s.index[block.Weak] = append(s.index[block.Weak], block)
+	}
+}
+
+//NewSignature reads content and returns its signature
+func NewSignature(reader io.Reader, blockSize int) (*Signature, error) {
+	if blockSize <= 0 {
+		return nil, fmt.Errorf("invalid block size: %v", blockSize)
+	}
+	result := &Signature{BlockSize: blockSize}
+	buffer := make([]byte, blockSize)
+	for {
+		n, err := io.ReadFull(reader, buffer)
+		if n > 0 {
+			result.Blocks = append(result.Blocks, &Block{
+				Offset: result.Size,
+				Length: n,
+				Weak:   weakSum(buffer[:n]),
+				Strong: md5.Sum(buffer[:n]),
+			})
+			result.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.init()
+	return result, nil
+}
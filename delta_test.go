@@ -0,0 +1,103 @@
+package afs
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
+	"math/rand"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestService_DeltaTransfer(t *testing.T) {
+	ctx := context.Background()
+	baseDir := os.TempDir()
+	content := make([]byte, 256*1024)
+	rand.New(rand.NewSource(7)).Read(content)
+	previous := append([]byte{}, content...)
+	copy(previous[100000:], "previous version")
+	previous = previous[:200*1024]
+
+	var useCases = []struct {
+		description string
+		sourceURL   string
+		destURL     string
+		sync        bool
+		prior       bool
+		expectDelta bool
+	}{
+		{
+			description: "sync to patcher",
+			sourceURL:   "mem://localhost/delta/src01",
+			destURL:     path.Join(baseDir, "afs_delta_dst01"),
+			sync:        true,
+			prior:       true,
+			expectDelta: true,
+		},
+		{
+			description: "sync without patcher",
+			sourceURL:   path.Join(baseDir, "afs_delta_src02"),
+			destURL:     "mem://localhost/delta/dst02",
+			sync:        true,
+			prior:       true,
+		},
+		{
+			description: "copy without patcher",
+			sourceURL:   path.Join(baseDir, "afs_delta_src03"),
+			destURL:     "mem://localhost/delta/dst03",
+			prior:       true,
+		},
+		{
+			description: "copy to patcher",
+			sourceURL:   path.Join(baseDir, "afs_delta_src05"),
+			destURL:     path.Join(baseDir, "afs_delta_dst05"),
+			prior:       true,
+			expectDelta: true,
+		},
+		{
+			description: "copy without prior version",
+			sourceURL:   "mem://localhost/delta/src04",
+			destURL:     path.Join(baseDir, "afs_delta_dst04"),
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := New()
+		_ = service.Delete(ctx, useCase.sourceURL)
+		_ = service.Delete(ctx, useCase.destURL)
+		if !assert.Nil(t, service.Upload(ctx, joinURL(useCase.sourceURL, "asset.bin"), file.DefaultFileOsMode, bytes.NewReader(content)), useCase.description) {
+			continue
+		}
+		if useCase.prior {
+			if !assert.Nil(t, service.Upload(ctx, joinURL(useCase.destURL, "asset.bin"), file.DefaultFileOsMode, bytes.NewReader(previous)), useCase.description) {
+				continue
+			}
+		} else {
+			_ = service.Create(ctx, useCase.destURL, file.DefaultDirOsMode, true)
+		}
+		deltaOption := option.NewDelta(4096)
+		var err error
+		if useCase.sync {
+			err = service.Sync(ctx, useCase.sourceURL, useCase.destURL, deltaOption)
+		} else {
+			err = service.Copy(ctx, useCase.sourceURL, useCase.destURL, deltaOption)
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, err := service.DownloadWithURL(ctx, joinURL(useCase.destURL, "asset.bin"))
+		assert.Nil(t, err, useCase.description)
+		assert.True(t, bytes.Equal(content, data), useCase.description)
+		if useCase.expectDelta {
+			assert.EqualValues(t, len(content), deltaOption.Matched+deltaOption.Literal, useCase.description)
+			assert.True(t, deltaOption.Literal <= 56*1024+4096, useCase.description)
+		} else {
+			assert.EqualValues(t, 0, deltaOption.Matched+deltaOption.Literal, useCase.description)
+		}
+		_ = service.Delete(ctx, useCase.sourceURL)
+		_ = service.Delete(ctx, useCase.destURL)
+	}
+}
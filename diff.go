@@ -0,0 +1,146 @@
+package afs
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/diff"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"sort"
+)
+
+const compareBufferSize = 32 * 1024
+
+//Diff compares left and right locations, added entries exist only on the right side, removed only on the left side,
+//option.Source and option.Dest supply left and right side options, option.Compare controls entries comparison (size and modification time by default)
+func (s *service) Diff(ctx context.Context, leftURL, rightURL string, options ...storage.Option) (diff.Changes, error) {
+	leftURL = url.Normalize(leftURL, file.Scheme)
+	rightURL = url.Normalize(rightURL, file.Scheme)
+	leftOptions := option.NewSource()
+	rightOptions := option.NewDest()
+	compare := &option.Compare{Size: true, ModTime: true}
+	option.Assign(options, &leftOptions, &rightOptions, &compare)
+	listOptions := matchOptions(options)
+	left, err := s.indexIfExists(ctx, leftURL, append(listOptions, *leftOptions...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list: %v", leftURL)
+	}
+	right, err := s.indexIfExists(ctx, rightURL, append(listOptions, *rightOptions...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list: %v", rightURL)
+	}
+	var paths = sortedKeys(left)
+	for relative := range right {
+		if _, ok := left[relative]; !ok {
+			paths = append(paths, relative)
+		}
+	}
+	sort.Strings(paths)
+	var result = make(diff.Changes, 0)
+	for _, relative := range paths {
+		leftObject, inLeft := left[relative]
+		rightObject, inRight := right[relative]
+		change := &diff.Change{Path: relative}
+		switch {
+		case !inRight:
+			change.Kind, change.Left = diff.Removed, leftObject
+		case !inLeft:
+			change.Kind, change.Right = diff.Added, rightObject
+		case leftObject.IsDir() != rightObject.IsDir():
+			change.Kind, change.Left, change.Right = diff.TypeChanged, leftObject, rightObject
+		case leftObject.IsDir():
+			continue
+		default:
+			different, err := s.isDifferent(ctx, leftObject, rightObject, compare, *leftOptions, *rightOptions)
+			if err != nil {
+				return nil, err
+			}
+			if !different {
+				continue
+			}
+			change.Kind, change.Left, change.Right = diff.Modified, leftObject, rightObject
+		}
+		result = append(result, change)
+	}
+	return result, nil
+}
+
+func (s *service) indexIfExists(ctx context.Context, URL string, options []storage.Option) (map[string]storage.Object, error) {
+	if exists, _ := s.Exists(ctx, URL, options...); !exists {
+		return map[string]storage.Object{}, nil
+	}
+	return s.index(ctx, URL, options)
+}
+
+//isDifferent compares files with supplied compare option
+func (s *service) isDifferent(ctx context.Context, left, right storage.Object, compare *option.Compare, leftOptions, rightOptions []storage.Option) (bool, error) {
+	if compare.Size && left.Size() != right.Size() {
+		return true, nil
+	}
+	if compare.ModTime {
+		elapsed := left.ModTime().Sub(right.ModTime())
+		if elapsed < 0 {
+			elapsed = -elapsed
+		}
+		if elapsed > compare.Tolerance {
+			return true, nil
+		}
+	}
+	if compare.Checksum {
+		leftHash, err := s.Checksum(ctx, left.URL(), checksum.MD5, leftOptions...)
+		if err != nil {
+			return false, err
+		}
+		rightHash, err := s.Checksum(ctx, right.URL(), checksum.MD5, rightOptions...)
+		if err != nil {
+			return false, err
+		}
+		if !bytes.Equal(leftHash, rightHash) {
+			return true, nil
+		}
+	}
+	if compare.Content {
+		equal, err := s.isContentEqual(ctx, left, right, leftOptions, rightOptions)
+		return !equal, err
+	}
+	return false, nil
+}
+
+//isContentEqual compares files byte by byte
+func (s *service) isContentEqual(ctx context.Context, left, right storage.Object, leftOptions, rightOptions []storage.Option) (bool, error) {
+	leftReader, err := s.Open(ctx, left, leftOptions...)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = leftReader.Close() }()
+	rightReader, err := s.Open(ctx, right, rightOptions...)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rightReader.Close() }()
+	leftBuffer := make([]byte, compareBufferSize)
+	rightBuffer := make([]byte, compareBufferSize)
+	for {
+		leftRead, leftErr := io.ReadFull(leftReader, leftBuffer)
+		rightRead, rightErr := io.ReadFull(rightReader, rightBuffer)
+		if !bytes.Equal(leftBuffer[:leftRead], rightBuffer[:rightRead]) {
+			return false, nil
+		}
+		leftEOF := leftErr == io.EOF || leftErr == io.ErrUnexpectedEOF
+		rightEOF := rightErr == io.EOF || rightErr == io.ErrUnexpectedEOF
+		if leftErr != nil && !leftEOF {
+			return false, leftErr
+		}
+		if rightErr != nil && !rightEOF {
+			return false, rightErr
+		}
+		if leftEOF || rightEOF {
+			return leftEOF == rightEOF, nil
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"os"
+)
+
+//Kind represents change kind
+type Kind string
+
+const (
+	//Added represents entry present only on the right side
+	Added = Kind("added")
+	//Removed represents entry present only on the left side
+	Removed = Kind("removed")
+	//Modified represents entry with different size, modification time or content
+	Modified = Kind("modified")
+	//TypeChanged represents entry being a file on one side and a folder on the other
+	TypeChanged = Kind("type-changed")
+)
+
+//Change represents tree entry difference, Path is relative to compared locations
+type Change struct {
+	Path  string
+	Kind  Kind
+	Left  os.FileInfo
+	Right os.FileInfo
+}
+
+//Changes represents changes sorted by path
+type Changes []*Change
+
+//Paths returns paths of changes with supplied kind
+func (c Changes) Paths(kind Kind) []string {
+	var result = make([]string, 0)
+	for _, change := range c {
+		if change.Kind == kind {
+			result = append(result, change.Path)
+		}
+	}
+	return result
+}
@@ -0,0 +1,2 @@
+//Package diff defines tree difference change set
+package diff
@@ -0,0 +1,159 @@
+package afs
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/diff"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_Diff(t *testing.T) {
+	ctx := context.Background()
+	baseDir := os.TempDir()
+	_, filename, _, _ := runtime.Caller(0)
+	testDir, _ := path.Split(filename)
+	ignore, _ := matcher.NewIgnore([]string{"*.log"})
+	now := time.Now()
+
+	var useCases = []struct {
+		description string
+		leftURL     string
+		rightURL    string
+		left        map[string]string
+		right       map[string]string
+		options     []storage.Option
+		expect      map[diff.Kind][]string
+	}{
+		{
+			description: "added, removed and type changed",
+			leftURL:     path.Join(baseDir, "afs_diff_left01"),
+			rightURL:    "mem://localhost/diff/right01",
+			left:        map[string]string{"a.txt": "abc", "b.txt": "xyz", "c/d.txt": "123"},
+			right:       map[string]string{"a.txt": "abc", "c": "file", "e/f.txt": "new"},
+			options:     []storage.Option{option.NewCompare(true, false, 0, false, false)},
+			expect: map[diff.Kind][]string{
+				diff.Added:       {"e", "e/f.txt"},
+				diff.Removed:     {"b.txt", "c/d.txt"},
+				diff.TypeChanged: {"c"},
+			},
+		},
+		{
+			description: "modified by size and mod time",
+			leftURL:     "mem://localhost/diff/left02",
+			rightURL:    "mem://localhost/diff/right02",
+			left:        map[string]string{"a.txt": "abc", "b.txt": "xyz"},
+			right:       map[string]string{"a.txt": "abcd", "b.txt": "xyz"},
+			expect: map[diff.Kind][]string{
+				diff.Modified: {"a.txt"},
+			},
+		},
+		{
+			description: "modified by checksum",
+			leftURL:     "mem://localhost/diff/left03",
+			rightURL:    "mem://localhost/diff/right03",
+			left:        map[string]string{"a.txt": "abc", "b.txt": "xyz"},
+			right:       map[string]string{"a.txt": "abd", "b.txt": "xyz"},
+			options:     []storage.Option{option.NewCompare(true, false, 0, true, false)},
+			expect: map[diff.Kind][]string{
+				diff.Modified: {"a.txt"},
+			},
+		},
+		{
+			description: "modified by content",
+			leftURL:     "mem://localhost/diff/left04",
+			rightURL:    path.Join(baseDir, "afs_diff_right04"),
+			left:        map[string]string{"a.txt": "abc", "b.txt": "xyz"},
+			right:       map[string]string{"a.txt": "abc", "b.txt": "xyy"},
+			options:     []storage.Option{option.NewCompare(false, false, 0, false, true)},
+			expect: map[diff.Kind][]string{
+				diff.Modified: {"b.txt"},
+			},
+		},
+		{
+			description: "mod time tolerance",
+			leftURL:     "mem://localhost/diff/left05",
+			rightURL:    path.Join(baseDir, "afs_diff_right05"),
+			left:        map[string]string{"a.txt": "abc"},
+			right:       map[string]string{"a.txt": "abc"},
+			options:     []storage.Option{option.NewCompare(true, true, time.Hour, false, false)},
+			expect:      map[diff.Kind][]string{},
+		},
+		{
+			description: "with ignore matcher",
+			leftURL:     "mem://localhost/diff/left06",
+			rightURL:    "mem://localhost/diff/right06",
+			left:        map[string]string{"a.txt": "abc", "x.log": "1"},
+			right:       map[string]string{"a.txt": "abc", "y.log": "2"},
+			options:     []storage.Option{ignore, option.NewCompare(true, false, 0, false, false)},
+			expect:      map[diff.Kind][]string{},
+		},
+		{
+			description: "zip snapshot",
+			leftURL:     fmt.Sprintf("file:%v/zip/test/app.war/zip://localhost/WEB-INF/classes", testDir),
+			rightURL:    "mem://localhost/diff/right07",
+			right:       map[string]string{"config.properties": "changed", "extra.txt": "extra"},
+			options:     []storage.Option{option.NewCompare(false, false, 0, true, false)},
+			expect: map[diff.Kind][]string{
+				diff.Added:    {"extra.txt"},
+				diff.Removed:  {"HelloWorld.class"},
+				diff.Modified: {"config.properties"},
+			},
+		},
+		{
+			description: "missing right side",
+			leftURL:     "mem://localhost/diff/left08",
+			rightURL:    "mem://localhost/diff/right08",
+			left:        map[string]string{"a.txt": "abc"},
+			expect: map[diff.Kind][]string{
+				diff.Removed: {"a.txt"},
+			},
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := New()
+		for _, location := range []string{useCase.leftURL, useCase.rightURL} {
+			if !strings.Contains(location, "zip:") {
+				_ = service.Delete(ctx, location)
+			}
+		}
+		if !assert.Nil(t, upload(ctx, service, useCase.leftURL, useCase.left, &now), useCase.description) {
+			continue
+		}
+		if !assert.Nil(t, upload(ctx, service, useCase.rightURL, useCase.right, &now), useCase.description) {
+			continue
+		}
+		changes, err := service.Diff(ctx, useCase.leftURL, useCase.rightURL, useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual := map[diff.Kind][]string{}
+		for _, change := range changes {
+			actual[change.Kind] = append(actual[change.Kind], change.Path)
+			switch change.Kind {
+			case diff.Added:
+				assert.Nil(t, change.Left, useCase.description)
+				assert.NotNil(t, change.Right, useCase.description)
+			case diff.Removed:
+				assert.NotNil(t, change.Left, useCase.description)
+				assert.Nil(t, change.Right, useCase.description)
+			default:
+				assert.NotNil(t, change.Left, useCase.description)
+				assert.NotNil(t, change.Right, useCase.description)
+			}
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		for kind, paths := range useCase.expect {
+			assert.EqualValues(t, paths, changes.Paths(kind), useCase.description)
+		}
+	}
+}
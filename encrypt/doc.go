@@ -0,0 +1,3 @@
+//Package encrypt defines client side envelope encryption storage manager decorator,
+//each object is encrypted with random data key (AES-256-GCM in streaming chunks) wrapped by supplied AES-256 key
+package encrypt
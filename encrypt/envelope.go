@@ -0,0 +1,123 @@
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	magic = "AFSE"
+	//version represents envelope format version
+	version = 1
+	//ChunkSize represents plaintext chunk size
+	ChunkSize    = 64 * 1024
+	maxChunkSize = 16 * 1024 * 1024
+	keySize      = 32
+	nonceSize    = 12
+	tagSize      = 16
+	prefixSize   = len(magic) + 1 + 4
+	//HeaderSize represents envelope header size: magic, version, chunk size, key nonce and wrapped data key
+	HeaderSize = prefixSize + nonceSize + keySize + tagSize
+)
+
+//ErrNotEncrypted represents not encrypted content error
+var ErrNotEncrypted = errors.New("content is not encrypted")
+
+type header struct {
+	chunkSize int
+	dataKey   []byte
+	encoded   []byte
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//newHeader creates a header with random data key wrapped by supplied key
+func newHeader(key []byte, chunkSize int) (*header, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	result := &header{chunkSize: chunkSize, dataKey: make([]byte, keySize), encoded: make([]byte, prefixSize+nonceSize, HeaderSize)}
+	if _, err = io.ReadFull(rand.Reader, result.dataKey); err != nil {
+		return nil, err
+	}
+	copy(result.encoded, magic)
+	result.encoded[len(magic)] = version
+	binary.BigEndian.PutUint32(result.encoded[len(magic)+1:prefixSize], uint32(chunkSize))
+	nonce := result.encoded[prefixSize:]
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	result.encoded = aead.Seal(result.encoded, nonce, result.dataKey, result.encoded[:prefixSize])
+	return result, nil
+}
+
+//decodeHeader decodes header and unwraps data key with supplied key
+func decodeHeader(encoded []byte, key []byte) (*header, error) {
+	if len(encoded) != HeaderSize {
+		return nil, ErrNotEncrypted
+	}
+	chunkSize, err := decodePrefix(encoded)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := encoded[prefixSize : prefixSize+nonceSize]
+	dataKey, err := aead.Open(nil, nonce, encoded[prefixSize+nonceSize:], encoded[:prefixSize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key, invalid key or corrupted content: %w", err)
+	}
+	return &header{chunkSize: chunkSize, dataKey: dataKey, encoded: encoded}, nil
+}
+
+//decodePrefix returns envelope chunk size after validating magic, version and chunk size
+func decodePrefix(prefix []byte) (int, error) {
+	if len(prefix) < prefixSize || string(prefix[:len(magic)]) != magic {
+		return 0, ErrNotEncrypted
+	}
+	if prefix[len(magic)] != version {
+		return 0, fmt.Errorf("unsupported envelope version: %v", prefix[len(magic)])
+	}
+	chunkSize := int(binary.BigEndian.Uint32(prefix[len(magic)+1 : prefixSize]))
+	if chunkSize == 0 || chunkSize > maxChunkSize {
+		return 0, fmt.Errorf("invalid envelope chunk size: %v", chunkSize)
+	}
+	return chunkSize, nil
+}
+
+//chunkNonce sets chunk nonce, the last chunk is flagged to detect truncation
+func chunkNonce(nonce []byte, counter uint64, last bool) {
+	binary.BigEndian.PutUint64(nonce, counter)
+	nonce[8], nonce[9], nonce[10], nonce[11] = 0, 0, 0, 0
+	if last {
+		nonce[nonceSize-1] = 1
+	}
+}
+
+//PlainSize returns plaintext size for supplied encrypted content size and envelope chunk size, -1 if size is not valid envelope size
+func PlainSize(size int64, chunkSize int) int64 {
+	size -= int64(HeaderSize)
+	if size < tagSize || chunkSize <= 0 {
+		return -1
+	}
+	sealedSize := int64(chunkSize + tagSize)
+	chunks := (size + sealedSize - 1) / sealedSize
+	if remainder := size % sealedSize; remainder > 0 && remainder < tagSize {
+		return -1
+	}
+	return size - chunks*tagSize
+}
@@ -0,0 +1,148 @@
+package encrypt
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"testing"
+)
+
+func TestNewWriter(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, keySize)
+	otherKey := bytes.Repeat([]byte{2}, keySize)
+	var useCases = []struct {
+		description string
+		size        int
+		modify      func(encrypted []byte) []byte
+		key         []byte
+		expectErr   error
+		hasError    bool
+	}{
+		{description: "empty content", size: 0},
+		{description: "small content", size: 10},
+		{description: "one chunk", size: ChunkSize},
+		{description: "one chunk and one byte", size: ChunkSize + 1},
+		{description: "multiple chunks", size: 3*ChunkSize + 123},
+		{description: "multiple full chunks", size: 2 * ChunkSize},
+		{
+			description: "wrong key",
+			size:        10,
+			key:         otherKey,
+			hasError:    true,
+		},
+		{
+			description: "tampered chunk",
+			size:        ChunkSize + 10,
+			modify: func(encrypted []byte) []byte {
+				encrypted[HeaderSize+5] ^= 1
+				return encrypted
+			},
+			hasError: true,
+		},
+		{
+			description: "truncated at chunk boundary",
+			size:        2*ChunkSize + 10,
+			modify: func(encrypted []byte) []byte {
+				return encrypted[:HeaderSize+2*(ChunkSize+tagSize)]
+			},
+			hasError: true,
+		},
+		{
+			description: "truncated header",
+			size:        10,
+			modify: func(encrypted []byte) []byte {
+				return encrypted[:HeaderSize-1]
+			},
+			expectErr: ErrNotEncrypted,
+		},
+		{
+			description: "missing last chunk",
+			size:        10,
+			modify: func(encrypted []byte) []byte {
+				return encrypted[:HeaderSize]
+			},
+			expectErr: ErrTruncated,
+		},
+		{
+			description: "plain content",
+			size:        10,
+			modify: func(encrypted []byte) []byte {
+				return bytes.Repeat([]byte("x"), HeaderSize+10)
+			},
+			expectErr: ErrNotEncrypted,
+		},
+	}
+
+	for _, useCase := range useCases {
+		content := make([]byte, useCase.size)
+		for i := range content {
+			content[i] = byte(i % 251)
+		}
+		buffer := new(bytes.Buffer)
+		writer, err := NewWriter(buffer, key)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		for offset := 0; offset < len(content); offset += 1000 {
+			end := offset + 1000
+			if end > len(content) {
+				end = len(content)
+			}
+			_, err = writer.Write(content[offset:end])
+			assert.Nil(t, err, useCase.description)
+		}
+		if !assert.Nil(t, writer.Close(), useCase.description) {
+			continue
+		}
+		encrypted := buffer.Bytes()
+		assert.EqualValues(t, useCase.size, PlainSize(int64(len(encrypted)), ChunkSize), useCase.description)
+		if useCase.modify != nil {
+			encrypted = useCase.modify(encrypted)
+		}
+		readerKey := key
+		if useCase.key != nil {
+			readerKey = useCase.key
+		}
+		var actual []byte
+		reader, err := NewReader(bytes.NewReader(encrypted), readerKey)
+		if err == nil {
+			actual, err = ioutil.ReadAll(reader)
+		}
+		if useCase.expectErr != nil {
+			assert.ErrorIs(t, err, useCase.expectErr, useCase.description)
+			continue
+		}
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, content, actual, useCase.description)
+	}
+}
+
+func TestPlainSize(t *testing.T) {
+	var useCases = []struct {
+		description string
+		size        int64
+		chunkSize   int
+		expect      int64
+	}{
+		{description: "empty file", size: 0, expect: -1},
+		{description: "header only", size: int64(HeaderSize), expect: -1},
+		{description: "empty content", size: int64(HeaderSize + tagSize), expect: 0},
+		{description: "full chunk", size: int64(HeaderSize + ChunkSize + tagSize), expect: ChunkSize},
+		{description: "chunk and byte", size: int64(HeaderSize + ChunkSize + 2*tagSize + 1), expect: ChunkSize + 1},
+		{description: "invalid last chunk", size: int64(HeaderSize + ChunkSize + tagSize + 3), expect: -1},
+		{description: "custom chunk size", size: int64(HeaderSize + 2*(1024+tagSize) + 10 + tagSize), chunkSize: 1024, expect: 2*1024 + 10},
+	}
+	for _, useCase := range useCases {
+		chunkSize := useCase.chunkSize
+		if chunkSize == 0 {
+			chunkSize = ChunkSize
+		}
+		assert.EqualValues(t, useCase.expect, PlainSize(useCase.size, chunkSize), useCase.description)
+	}
+}
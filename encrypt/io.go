@@ -0,0 +1,68 @@
+package encrypt
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+)
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type writeCloser struct {
+	io.WriteCloser
+	dest io.WriteCloser
+	sums *checksums
+}
+
+//Write encrypts content updating plaintext checksums
+func (w *writeCloser) Write(p []byte) (int, error) {
+	_, _ = w.sums.Write(p)
+	return w.WriteCloser.Write(p)
+}
+
+//Close writes the last chunk and closes destination writer, plaintext checksum mismatch aborts upload writer
+func (w *writeCloser) Close() error {
+	if err := w.sums.Verify(); err != nil {
+		if writer, ok := w.dest.(*uploadWriter); ok {
+			_ = writer.CloseWithError(err)
+			<-writer.done
+		} else {
+			_ = w.dest.Close()
+		}
+		return err
+	}
+	err := w.WriteCloser.Close()
+	if closeErr := w.dest.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//uploadWriter uploads written content with manager Upload
+type uploadWriter struct {
+	*io.PipeWriter
+	done chan error
+}
+
+//Close completes upload
+func (w *uploadWriter) Close() error {
+	if err := w.PipeWriter.Close(); err != nil {
+		return err
+	}
+	return <-w.done
+}
+
+func newUploadWriter(ctx context.Context, uploader storage.Uploader, URL string, mode os.FileMode, options []storage.Option) *uploadWriter {
+	pipeReader, pipeWriter := io.Pipe()
+	result := &uploadWriter{PipeWriter: pipeWriter, done: make(chan error, 1)}
+	go func() {
+		err := uploader.Upload(ctx, URL, mode, pipeReader, options...)
+		_ = pipeReader.CloseWithError(err)
+		result.done <- err
+	}()
+	return result
+}
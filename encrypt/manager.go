@@ -0,0 +1,134 @@
+package encrypt
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+)
+
+type manager struct {
+	storage.Manager
+	key []byte
+}
+
+//List lists underlying objects reporting plaintext size of encrypted files, envelope prefix is read to detect encrypted files
+func (m *manager) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	objects, err := m.Manager.List(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	for i, candidate := range objects {
+		if candidate.IsDir() {
+			continue
+		}
+		if candidate.Size() < int64(HeaderSize+tagSize) {
+			continue
+		}
+		chunkSize, err := m.chunkSize(ctx, candidate.URL())
+		if err != nil {
+			continue
+		}
+		if size := PlainSize(candidate.Size(), chunkSize); size >= 0 {
+			info := file.NewInfo(candidate.Name(), size, candidate.Mode(), candidate.ModTime(), false)
+			objects[i] = object.New(candidate.URL(), info, candidate)
+		}
+	}
+	return objects, nil
+}
+
+//chunkSize reads envelope prefix returning its chunk size, ErrNotEncrypted if content is not encrypted
+func (m *manager) chunkSize(ctx context.Context, URL string) (int, error) {
+	reader, err := m.Manager.OpenURL(ctx, URL)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	prefix := make([]byte, prefixSize)
+	if _, err = io.ReadFull(reader, prefix); err != nil {
+		return 0, err
+	}
+	return decodePrefix(prefix)
+}
+
+//Open returns decrypting reader for supplied object
+func (m *manager) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
+	return m.OpenURL(ctx, object.URL(), options...)
+}
+
+//OpenURL returns decrypting reader for supplied URL, option.Range applies to decrypted content
+func (m *manager) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	byteRange := &option.Range{}
+	options, hasRange := option.Assign(options, &byteRange)
+	source, err := m.Manager.OpenURL(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if reader, err = NewReader(source, m.key); err == nil && hasRange {
+		reader, err = rangeReader(reader, byteRange)
+	}
+	if err != nil {
+		_ = source.Close()
+		return nil, fmt.Errorf("failed to open %v: %w", URL, err)
+	}
+	return &readCloser{Reader: reader, Closer: source}, nil
+}
+
+//Upload encrypts and uploads content, option.Md5 and option.Crc are verified against plaintext
+func (m *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	sums, options := newChecksums(options)
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		writer, err := NewWriter(pipeWriter, m.key)
+		if err == nil {
+			if _, err = io.Copy(writer, io.TeeReader(reader, sums)); err == nil {
+				if err = sums.Verify(); err == nil {
+					err = writer.Close()
+				}
+			}
+		}
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	err := m.Manager.Upload(ctx, URL, mode, pipeReader, options...)
+	_ = pipeReader.CloseWithError(err)
+	return err
+}
+
+//NewWriter returns encrypting writer, option.Md5 and option.Crc are verified against plaintext on Close
+func (m *manager) NewWriter(ctx context.Context, URL string, mode os.FileMode, options ...storage.Option) (io.WriteCloser, error) {
+	sums, options := newChecksums(options)
+	var dest io.WriteCloser
+	if provider, ok := m.Manager.(storage.WriterProvider); ok {
+		var err error
+		if dest, err = provider.NewWriter(ctx, URL, mode, options...); err != nil {
+			return nil, err
+		}
+	} else {
+		dest = newUploadWriter(ctx, m.Manager, URL, mode, options)
+	}
+	writer, err := NewWriter(dest, m.key)
+	if err != nil {
+		_ = dest.Close()
+		return nil, err
+	}
+	return &writeCloser{WriteCloser: writer, dest: dest, sums: sums}, nil
+}
+
+//New returns manager decorator encrypting uploaded and decrypting downloaded content with data key wrapped by supplied key
+func New(delegate storage.Manager, key *option.AES256Key) (storage.Manager, error) {
+	if key == nil {
+		return nil, fmt.Errorf("key was empty")
+	}
+	if err := key.Init(); err != nil {
+		return nil, err
+	}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	return &manager{Manager: delegate, key: key.Key}, nil
+}
@@ -0,0 +1,248 @@
+package encrypt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestManager_Upload(t *testing.T) {
+	ctx := context.Background()
+	key, err := option.NewAES256Key(bytes.Repeat([]byte{7}, 32))
+	if !assert.Nil(t, err) {
+		return
+	}
+	baseDir := path.Join(os.TempDir(), "afs_encrypt")
+	defer func() { _ = os.RemoveAll(baseDir) }()
+	content := strings.Repeat("secret content ", 10000)
+
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		URL         string
+		useWriter   bool
+	}{
+		{description: "mem upload", manager: mem.New(), URL: "mem://localhost/encrypt/upload.txt"},
+		{description: "mem writer", manager: mem.New(), URL: "mem://localhost/encrypt/writer.txt", useWriter: true},
+		{description: "file upload", manager: file.New(), URL: path.Join(baseDir, "upload.txt")},
+		{description: "file writer", manager: file.New(), URL: path.Join(baseDir, "writer.txt"), useWriter: true},
+	}
+
+	for _, useCase := range useCases {
+		manager, err := New(useCase.manager, key)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.useWriter {
+			writer, err := manager.(storage.WriterProvider).NewWriter(ctx, useCase.URL, file.DefaultFileOsMode)
+			if !assert.Nil(t, err, useCase.description) {
+				continue
+			}
+			_, err = writer.Write([]byte(content))
+			assert.Nil(t, err, useCase.description)
+			err = writer.Close()
+		} else {
+			err = manager.Upload(ctx, useCase.URL, file.DefaultFileOsMode, strings.NewReader(content))
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+
+		raw, err := useCase.manager.OpenURL(ctx, useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		encrypted, _ := ioutil.ReadAll(raw)
+		_ = raw.Close()
+		assert.False(t, bytes.Contains(encrypted, []byte("secret")), useCase.description)
+
+		objects, err := manager.List(ctx, useCase.URL)
+		if assert.Nil(t, err, useCase.description) && assert.EqualValues(t, 1, len(objects), useCase.description) {
+			assert.EqualValues(t, len(content), objects[0].Size(), useCase.description)
+		}
+		reader, err := manager.OpenURL(ctx, useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, content, string(actual), useCase.description)
+	}
+}
+
+func TestManager_List(t *testing.T) {
+	ctx := context.Background()
+	key, _ := option.NewAES256Key(bytes.Repeat([]byte{7}, 32))
+	delegate := mem.New()
+	baseURL := "mem://localhost/encrypt/list"
+	manager, _ := New(delegate, key)
+	encrypted := strings.Repeat("secret content ", 100)
+	if !assert.Nil(t, manager.Upload(ctx, baseURL+"/encrypted.txt", file.DefaultFileOsMode, strings.NewReader(encrypted))) {
+		return
+	}
+	plain := strings.Repeat("plain content ", 100)
+	if !assert.Nil(t, delegate.Upload(ctx, baseURL+"/plain.txt", file.DefaultFileOsMode, strings.NewReader(plain))) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		name        string
+		expectSize  int
+	}{
+		{description: "encrypted file", name: "encrypted.txt", expectSize: len(encrypted)},
+		{description: "unencrypted neighbour", name: "plain.txt", expectSize: len(plain)},
+	}
+	objects, err := manager.List(ctx, baseURL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	sizes := map[string]int64{}
+	for _, object := range objects {
+		sizes[object.Name()] = object.Size()
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expectSize, sizes[useCase.name], useCase.description)
+	}
+}
+
+func TestManager_OpenURL(t *testing.T) {
+	ctx := context.Background()
+	key, _ := option.NewAES256Key(bytes.Repeat([]byte{7}, 32))
+	otherKey, _ := option.NewAES256Key(bytes.Repeat([]byte{8}, 32))
+	delegate := mem.New()
+	URL := "mem://localhost/encrypt/open.txt"
+	manager, _ := New(delegate, key)
+	if !assert.Nil(t, manager.Upload(ctx, URL, file.DefaultFileOsMode, strings.NewReader("abc"))) {
+		return
+	}
+	plainURL := "mem://localhost/encrypt/plain.txt"
+	if !assert.Nil(t, delegate.Upload(ctx, plainURL, file.DefaultFileOsMode, strings.NewReader("plain"))) {
+		return
+	}
+	otherManager, _ := New(delegate, otherKey)
+
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		URL         string
+		expectErr   error
+		hasError    bool
+	}{
+		{description: "valid key", manager: manager, URL: URL},
+		{description: "wrong key", manager: otherManager, URL: URL, hasError: true},
+		{description: "not encrypted", manager: manager, URL: plainURL, expectErr: ErrNotEncrypted},
+		{description: "missing", manager: manager, URL: "mem://localhost/encrypt/missing.txt", hasError: true},
+	}
+	for _, useCase := range useCases {
+		reader, err := useCase.manager.OpenURL(ctx, useCase.URL)
+		if useCase.expectErr != nil {
+			assert.ErrorIs(t, err, useCase.expectErr, useCase.description)
+			continue
+		}
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, _ := ioutil.ReadAll(reader)
+		assert.EqualValues(t, "abc", string(actual), useCase.description)
+	}
+
+	_, err := New(delegate, &option.AES256Key{Key: []byte("short")})
+	assert.NotNil(t, err)
+}
+
+func TestManager_OpenURL_Range(t *testing.T) {
+	ctx := context.Background()
+	key, _ := option.NewAES256Key(bytes.Repeat([]byte{7}, 32))
+	manager, _ := New(mem.New(), key)
+	URL := "mem://localhost/encrypt/range.txt"
+	content := strings.Repeat("0123456789", 20000)
+	if !assert.Nil(t, manager.Upload(ctx, URL, file.DefaultFileOsMode, strings.NewReader(content))) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		byteRange   *option.Range
+		expect      string
+	}{
+		{description: "head", byteRange: option.NewRange(0, 5), expect: content[:5]},
+		{description: "across chunks", byteRange: option.NewRange(65530, 20), expect: content[65530:65550]},
+		{description: "till the end", byteRange: option.NewRange(199990, 0), expect: content[199990:]},
+		{description: "past the end", byteRange: option.NewRange(300000, 10), expect: ""},
+	}
+	for _, useCase := range useCases {
+		reader, err := manager.OpenURL(ctx, URL, useCase.byteRange)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(actual), useCase.description)
+	}
+}
+
+func TestManager_Upload_Checksum(t *testing.T) {
+	ctx := context.Background()
+	key, _ := option.NewAES256Key(bytes.Repeat([]byte{7}, 32))
+	content := []byte("secret content")
+
+	var useCases = []struct {
+		description string
+		options     []storage.Option
+		useWriter   bool
+		hasError    bool
+	}{
+		{description: "upload with plaintext md5", options: []storage.Option{option.NewMd5(content)}},
+		{description: "upload with plaintext crc", options: []storage.Option{option.NewCrc(content)}},
+		{description: "upload with invalid md5", options: []storage.Option{option.NewMd5([]byte("other"))}, hasError: true},
+		{description: "writer with plaintext md5", options: []storage.Option{option.NewMd5(content)}, useWriter: true},
+		{description: "writer with invalid crc", options: []storage.Option{option.NewCrc([]byte("other"))}, useWriter: true, hasError: true},
+	}
+	for i, useCase := range useCases {
+		delegate := mem.New()
+		manager, _ := New(delegate, key)
+		URL := fmt.Sprintf("mem://localhost/encrypt/checksum%v.txt", i)
+		var err error
+		if useCase.useWriter {
+			writer, wErr := manager.(storage.WriterProvider).NewWriter(ctx, URL, file.DefaultFileOsMode, useCase.options...)
+			if !assert.Nil(t, wErr, useCase.description) {
+				continue
+			}
+			_, _ = writer.Write(content)
+			err = writer.Close()
+		} else {
+			err = manager.Upload(ctx, URL, file.DefaultFileOsMode, bytes.NewReader(content), useCase.options...)
+		}
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := manager.OpenURL(ctx, URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, _ := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.EqualValues(t, content, actual, useCase.description)
+	}
+}
@@ -0,0 +1,73 @@
+package encrypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+)
+
+//checksums verifies option.Md5 and option.Crc against plaintext, delegate would validate them against ciphertext
+type checksums struct {
+	md5    *option.Md5
+	crc    *option.Crc
+	hashes []hash.Hash
+}
+
+//Write updates plaintext hashes
+func (c *checksums) Write(p []byte) (int, error) {
+	for _, aHash := range c.hashes {
+		_, _ = aHash.Write(p)
+	}
+	return len(p), nil
+}
+
+//Verify returns an error if plaintext checksum does not match supplied option
+func (c *checksums) Verify() error {
+	if c.md5 != nil {
+		if actual := c.hashes[0].Sum(nil); !bytes.Equal(actual, c.md5.Hash) {
+			return fmt.Errorf("md5 checksum mismatch: expected %x, but had %x", c.md5.Hash, actual)
+		}
+	}
+	if c.crc != nil {
+		if actual := c.hashes[len(c.hashes)-1].(hash.Hash32).Sum32(); actual != c.crc.Hash {
+			return fmt.Errorf("crc checksum mismatch: expected %v, but had %v", c.crc.Hash, actual)
+		}
+	}
+	return nil
+}
+
+//newChecksums removes plaintext checksum options from delegate options
+func newChecksums(options []storage.Option) (*checksums, []storage.Option) {
+	md5Option := &option.Md5{}
+	crcOption := &option.Crc{}
+	options, _ = option.Assign(options, &md5Option, &crcOption)
+	result := &checksums{}
+	if len(md5Option.Hash) > 0 {
+		result.md5 = md5Option
+		result.hashes = append(result.hashes, md5.New())
+	}
+	if crcOption.Hash != 0 {
+		result.crc = crcOption
+		result.hashes = append(result.hashes, crc32.New(crc32.MakeTable(crc32.Castagnoli)))
+	}
+	return result, options
+}
+
+//rangeReader returns reader limited to byte range of decrypted content, chunks preceding range are decrypted and discarded
+func rangeReader(reader io.Reader, byteRange *option.Range) (io.Reader, error) {
+	if byteRange.Offset > 0 {
+		if _, err := io.CopyN(ioutil.Discard, reader, byteRange.Offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	if byteRange.Length > 0 {
+		return io.LimitReader(reader, byteRange.Length), nil
+	}
+	return reader, nil
+}
@@ -0,0 +1,93 @@
+package encrypt
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+)
+
+//ErrTruncated represents truncated encrypted content error
+var ErrTruncated = errors.New("encrypted content is truncated")
+
+type reader struct {
+	source  *bufio.Reader
+	aead    cipher.AEAD
+	header  *header
+	chunk   []byte
+	buffer  []byte
+	plain   []byte
+	nonce   []byte
+	counter uint64
+	last    bool
+}
+
+//Read reads decrypted content
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.last {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+func (r *reader) next() error {
+	n, err := io.ReadFull(r.source, r.chunk)
+	switch err {
+	case nil:
+		if _, err = r.source.Peek(1); err == io.EOF {
+			r.last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		r.last = true
+	case io.EOF:
+		return ErrTruncated
+	default:
+		return err
+	}
+	if n < tagSize {
+		return ErrTruncated
+	}
+	chunkNonce(r.nonce, r.counter, r.last)
+	if r.plain, err = r.aead.Open(r.buffer[:0], r.nonce, r.chunk[:n], r.header.encoded); err != nil {
+		return fmt.Errorf("failed to decrypt chunk %v: %w", r.counter, err)
+	}
+	r.counter++
+	return nil
+}
+
+//NewReader returns reader decrypting content created with NewWriter
+func NewReader(source io.Reader, key []byte) (io.Reader, error) {
+	encoded := make([]byte, HeaderSize)
+	if _, err := io.ReadFull(source, encoded); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrNotEncrypted
+		}
+		return nil, err
+	}
+	header, err := decodeHeader(encoded, key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(header.dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{
+		source: bufio.NewReader(source),
+		aead:   aead,
+		header: header,
+		chunk:  make([]byte, header.chunkSize+tagSize),
+		buffer: make([]byte, 0, header.chunkSize),
+		nonce:  make([]byte, nonceSize),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package encrypt
+
+import (
+	"crypto/cipher"
+	"errors"
+	"io"
+)
+
+type writer struct {
+	dest    io.Writer
+	aead    cipher.AEAD
+	header  *header
+	pending []byte
+	sealed  []byte
+	nonce   []byte
+	counter uint64
+	closed  bool
+}
+
+//Write buffers and encrypts data, full chunk is sealed only when more data follows
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("writer is closed")
+	}
+	written := 0
+	for len(p) > 0 {
+		if len(w.pending) == w.header.chunkSize {
+			if err := w.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(w.pending[len(w.pending):w.header.chunkSize], p)
+		w.pending = w.pending[:len(w.pending)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *writer) flush(last bool) error {
+	chunkNonce(w.nonce, w.counter, last)
+	w.sealed = w.aead.Seal(w.sealed[:0], w.nonce, w.pending, w.header.encoded)
+	w.counter++
+	w.pending = w.pending[:0]
+	_, err := w.dest.Write(w.sealed)
+	return err
+}
+
+//Close seals the last chunk, it does not close underlying writer
+func (w *writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.flush(true)
+}
+
+//NewWriter returns writer encrypting content with random data key wrapped by supplied key, Close writes the last chunk
+func NewWriter(dest io.Writer, key []byte) (io.WriteCloser, error) {
+	header, err := newHeader(key, ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(header.dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = dest.Write(header.encoded); err != nil {
+		return nil, err
+	}
+	return &writer{
+		dest:    dest,
+		aead:    aead,
+		header:  header,
+		pending: make([]byte, 0, ChunkSize),
+		sealed:  make([]byte, 0, ChunkSize+tagSize),
+		nonce:   make([]byte, nonceSize),
+	}, nil
+}
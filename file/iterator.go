@@ -0,0 +1,65 @@
+package file
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"os"
+)
+
+const listIterBatchSize = 256
+
+//ListIter returns an iterator reading directory entries in batches (directory order), continuation token resumes after the returned entries count
+func ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	token, err := iterator.Continuation(options)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, filePath := url.Base(URL, Scheme)
+	stat, err := os.Stat(Path(filePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open "+filePath)
+	}
+	match, _ := option.GetListOptions(options)
+	return iterator.NewStream(ctx, func(ctx context.Context, emit func(object storage.Object) bool) error {
+		if !stat.IsDir() {
+			emit(object.New(URL, stat, nil))
+			return nil
+		}
+		dir, err := os.Open(Path(filePath))
+		if err != nil {
+			return errors.Wrap(err, "unable to open "+filePath)
+		}
+		defer func() { _ = dir.Close() }()
+		for {
+			entries, err := dir.ReadDir(listIterBatchSize)
+			for _, entry := range entries {
+				fileInfo, infoErr := entry.Info()
+				if infoErr != nil {
+					if os.IsNotExist(infoErr) {
+						continue
+					}
+					return infoErr
+				}
+				if !match(filePath, fileInfo) {
+					continue
+				}
+				fileInfo = linkInfo(baseURL, filePath, fileInfo)
+				if !emit(object.New(url.Join(baseURL, filePath, fileInfo.Name()), fileInfo, nil)) {
+					return nil
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}, token), nil
+}
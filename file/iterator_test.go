@@ -0,0 +1,95 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"testing"
+)
+
+func TestManager_ListIter(t *testing.T) {
+	baseDir := os.TempDir()
+	ctx := context.Background()
+	fileManager := New()
+	csvMatcher, _ := matcher.NewBasic("", ".csv", "", nil)
+
+	var useCases = []struct {
+		description string
+		location    string
+		assets      int
+		pageSize    int
+		matcher     option.Match
+		expect      int
+	}{
+		{
+			description: "iterate all entries",
+			location:    path.Join(baseDir, "file_iter_001"),
+			assets:      5,
+			pageSize:    10,
+			expect:      5,
+		},
+		{
+			description: "iterate with continuation",
+			location:    path.Join(baseDir, "file_iter_002"),
+			assets:      700,
+			pageSize:    300,
+			expect:      700,
+		},
+		{
+			description: "iterate with matcher",
+			location:    path.Join(baseDir, "file_iter_003"),
+			assets:      10,
+			pageSize:    2,
+			matcher:     csvMatcher.Match,
+			expect:      5,
+		},
+	}
+
+	for _, useCase := range useCases {
+		_ = os.RemoveAll(useCase.location)
+		_ = os.MkdirAll(useCase.location, 0744)
+		for i := 0; i < useCase.assets; i++ {
+			ext := ".txt"
+			if i%2 == 0 {
+				ext = ".csv"
+			}
+			_ = ioutil.WriteFile(path.Join(useCase.location, fmt.Sprintf("asset%04d%v", i, ext)), []byte("abc"), 0644)
+		}
+		var actual []string
+		token := ""
+		for {
+			options := []storage.Option{option.NewContinuation(token)}
+			if useCase.matcher != nil {
+				options = append(options, useCase.matcher)
+			}
+			iter, err := fileManager.(*manager).ListIter(ctx, useCase.location, options...)
+			if !assert.Nil(t, err, useCase.description) {
+				break
+			}
+			count := 0
+			for count < useCase.pageSize && iter.Next() {
+				actual = append(actual, iter.Object().Name())
+				token = iter.Token()
+				count++
+			}
+			assert.Nil(t, iter.Err(), useCase.description)
+			assert.Nil(t, iter.Close(), useCase.description)
+			if count < useCase.pageSize {
+				break
+			}
+		}
+		assert.EqualValues(t, useCase.expect, len(actual), useCase.description)
+		sort.Strings(actual)
+		for i := 1; i < len(actual); i++ {
+			assert.NotEqual(t, actual[i-1], actual[i], useCase.description)
+		}
+		_ = os.RemoveAll(useCase.location)
+	}
+}
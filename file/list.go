@@ -45,12 +45,7 @@ func List(ctx context.Context, URL string, options ...storage.Option) ([]storage
 			continue
 		}
 
-		if fileInfo.Mode()&os.ModeSymlink > 0 {
-			linkname, err := os.Readlink(path.Join(filePath, fileInfo.Name()))
-			if err == nil {
-				fileInfo = NewInfo(fileInfo.Name(), fileInfo.Size(), fileInfo.Mode(), fileInfo.ModTime(), fileInfo.IsDir(), object.NewLink(linkname, url.Join(baseURL, linkname), fileInfo))
-			}
-		}
+		fileInfo = linkInfo(baseURL, filePath, fileInfo)
 		fileURL := url.Join(baseURL, filePath, fileInfo.Name())
 		result = append(result, object.New(fileURL, fileInfo, nil))
 		if page.HasReachedLimit() {
@@ -59,3 +54,15 @@ func List(ctx context.Context, URL string, options ...storage.Option) ([]storage
 	}
 	return result, nil
 }
+
+//linkInfo returns file info with link details for symbolic link
+func linkInfo(baseURL, filePath string, fileInfo os.FileInfo) os.FileInfo {
+	if fileInfo.Mode()&os.ModeSymlink == 0 {
+		return fileInfo
+	}
+	linkname, err := os.Readlink(path.Join(filePath, fileInfo.Name()))
+	if err != nil {
+		return fileInfo
+	}
+	return NewInfo(fileInfo.Name(), fileInfo.Size(), fileInfo.Mode(), fileInfo.ModTime(), fileInfo.IsDir(), object.NewLink(linkname, url.Join(baseURL, linkname), fileInfo))
+}
@@ -13,10 +13,22 @@ func (s *manager) List(ctx context.Context, URL string, options ...storage.Optio
 	return List(ctx, URL, options...)
 }
 
+func (s *manager) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	return ListIter(ctx, URL, options...)
+}
+
 func (s *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
 	return Upload(ctx, URL, mode, reader, options...)
 }
 
+func (s *manager) Patch(ctx context.Context, URL string, patch func(base io.ReaderAt, writer io.Writer) error, options ...storage.Option) error {
+	return Patch(ctx, URL, patch, options...)
+}
+
+func (s *manager) Overwrite(ctx context.Context, URL string, overwrite func(base io.ReaderAt, size int64, writer io.WriteSeeker) error, options ...storage.Option) error {
+	return Overwrite(ctx, URL, overwrite, options...)
+}
+
 func (s *manager) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
 	return Open(ctx, object, options...)
 }
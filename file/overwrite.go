@@ -0,0 +1,46 @@
+package file
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+)
+
+//Overwrite modifies file in place, if anything was written file is truncated at the last writer offset
+func Overwrite(ctx context.Context, URL string, overwrite func(base io.ReaderAt, size int64, writer io.WriteSeeker) error, options ...storage.Option) error {
+	filePath := Path(URL)
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrap(err, "unable to open "+filePath)
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	writer := &writeTracker{File: file}
+	err = overwrite(file, stat.Size(), writer)
+	if err == nil && writer.written {
+		var offset int64
+		if offset, err = file.Seek(0, io.SeekCurrent); err == nil {
+			err = file.Truncate(offset)
+		}
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//writeTracker tracks if file was written
+type writeTracker struct {
+	*os.File
+	written bool
+}
+
+func (w *writeTracker) Write(p []byte) (int, error) {
+	w.written = true
+	return w.File.Write(p)
+}
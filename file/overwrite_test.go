@@ -0,0 +1,79 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestOverwrite(t *testing.T) {
+	ctx := context.Background()
+	location := path.Join(os.TempDir(), "file_overwrite_001.txt")
+	defer func() { _ = os.Remove(location) }()
+
+	var useCases = []struct {
+		description string
+		overwrite   func(base io.ReaderAt, size int64, writer io.WriteSeeker) error
+		expect      string
+		hasError    bool
+	}{
+		{
+			description: "overwrite tail",
+			overwrite: func(base io.ReaderAt, size int64, writer io.WriteSeeker) error {
+				if _, err := writer.Seek(size-7, io.SeekStart); err != nil {
+					return err
+				}
+				_, err := writer.Write([]byte("new"))
+				return err
+			},
+			expect: "abc new",
+		},
+		{
+			description: "overwrite past end",
+			overwrite: func(base io.ReaderAt, size int64, writer io.WriteSeeker) error {
+				if _, err := writer.Seek(size, io.SeekStart); err != nil {
+					return err
+				}
+				_, err := writer.Write([]byte(" appended"))
+				return err
+			},
+			expect: "abc content appended",
+		},
+		{
+			description: "nothing written",
+			overwrite: func(base io.ReaderAt, size int64, writer io.WriteSeeker) error {
+				return nil
+			},
+			expect: "abc content",
+		},
+		{
+			description: "failed overwrite",
+			overwrite: func(base io.ReaderAt, size int64, writer io.WriteSeeker) error {
+				return errors.New("test error")
+			},
+			expect:   "abc content",
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		_ = ioutil.WriteFile(location, []byte("abc content"), 0640)
+		before, _ := os.Stat(location)
+		err := Overwrite(ctx, location, useCase.overwrite)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+		} else {
+			assert.Nil(t, err, useCase.description)
+		}
+		data, err := ioutil.ReadFile(location)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+		after, _ := os.Stat(location)
+		assert.True(t, os.SameFile(before, after), useCase.description)
+	}
+}
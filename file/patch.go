@@ -0,0 +1,43 @@
+package file
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+//Patch rewrites file using its current content as base, new content is written to a temporary file renamed on success
+func Patch(ctx context.Context, URL string, patch func(base io.ReaderAt, writer io.Writer) error, options ...storage.Option) error {
+	filePath := Path(URL)
+	base, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrap(err, "unable to open "+filePath)
+	}
+	defer func() { _ = base.Close() }()
+	stat, err := base.Stat()
+	if err != nil {
+		return err
+	}
+	dir, name := filepath.Split(filePath)
+	temp, err := ioutil.TempFile(dir, "."+name+".patch")
+	if err != nil {
+		return err
+	}
+	if err = patch(base, temp); err == nil {
+		err = temp.Chmod(stat.Mode().Perm())
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), filePath)
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+	location := path.Join(os.TempDir(), "file_patch_001.txt")
+
+	var useCases = []struct {
+		description string
+		patch       func(base io.ReaderAt, writer io.Writer) error
+		expect      string
+		hasError    bool
+	}{
+		{
+			description: "patch with base content",
+			patch: func(base io.ReaderAt, writer io.Writer) error {
+				if _, err := io.Copy(writer, io.NewSectionReader(base, 0, 4)); err != nil {
+					return err
+				}
+				_, err := writer.Write([]byte("patched"))
+				return err
+			},
+			expect: "abc patched",
+		},
+		{
+			description: "failed patch",
+			patch: func(base io.ReaderAt, writer io.Writer) error {
+				_, _ = writer.Write([]byte("partial"))
+				return errors.New("test error")
+			},
+			expect:   "abc content",
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		_ = ioutil.WriteFile(location, []byte("abc content"), 0640)
+		err := Patch(ctx, location, useCase.patch)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+		} else {
+			assert.Nil(t, err, useCase.description)
+		}
+		data, err := ioutil.ReadFile(location)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+		stat, _ := os.Stat(location)
+		assert.EqualValues(t, os.FileMode(0640), stat.Mode().Perm(), useCase.description)
+		temps, _ := filepath.Glob(path.Join(os.TempDir(), ".file_patch_001.txt.patch*"))
+		assert.EqualValues(t, 0, len(temps), useCase.description)
+	}
+	_ = os.Remove(location)
+}
@@ -5,15 +5,18 @@ go 1.17
 require (
 	github.com/go-errors/errors v1.4.2
 	github.com/pkg/errors v0.9.1
+	github.com/pkg/sftp v1.13.6
 	github.com/stretchr/testify v1.8.1
 	github.com/viant/toolbox v0.34.6-0.20221112031702-3e7cdde7f888
 	github.com/viant/xunsafe v0.9.2
 	golang.org/x/crypto v0.3.0
+	golang.org/x/net v0.2.0
 )
 
 require (
 	cloud.google.com/go v0.65.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kr/fs v0.1.0 // indirect
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/viant/xreflect v0.0.0-20230303201326-f50afb0feb0d // indirect
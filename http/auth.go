@@ -0,0 +1,33 @@
+package http
+
+import (
+	"context"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+)
+
+//IsAuthChanged returns true if supplied token source or basic auth differs from the manager one
+func (s *manager) IsAuthChanged(ctx context.Context, baseURL string, options []storage.Option) bool {
+	auth := &option.Auth{}
+	if _, ok := option.Assign(options, &auth); ok && auth.Force {
+		return true
+	}
+	var tokenSource, managerTokenSource TokenSource
+	var basicAuth, managerBasicAuth option.BasicAuth
+	option.Assign(options, &tokenSource, &basicAuth)
+	option.Assign(s.options, &managerTokenSource, &managerBasicAuth)
+	if tokenSource != nil {
+		if managerTokenSource == nil || !sameTokenSource(managerTokenSource, tokenSource) {
+			return true
+		}
+	}
+	if basicAuth != nil {
+		if managerBasicAuth == nil {
+			return true
+		}
+		user, password := basicAuth.Credentials()
+		managerUser, managerPassword := managerBasicAuth.Credentials()
+		return user != managerUser || password != managerPassword
+	}
+	return false
+}
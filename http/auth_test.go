@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"testing"
+)
+
+func TestManager_IsAuthChanged(t *testing.T) {
+	credentials := NewClientCredentials("http://localhost/token", "client", "secret")
+	var useCases = []struct {
+		description    string
+		managerOptions []storage.Option
+		options        []storage.Option
+		expect         bool
+	}{
+		{
+			description: "no auth",
+		},
+		{
+			description:    "same bearer",
+			managerOptions: []storage.Option{NewBearer("abc")},
+			options:        []storage.Option{NewBearer("abc")},
+		},
+		{
+			description:    "changed bearer",
+			managerOptions: []storage.Option{NewBearer("abc")},
+			options:        []storage.Option{NewBearer("xyz")},
+			expect:         true,
+		},
+		{
+			description:    "same client credentials",
+			managerOptions: []storage.Option{credentials},
+			options:        []storage.Option{NewClientCredentials("http://localhost/token", "client", "secret")},
+		},
+		{
+			description:    "changed client credentials",
+			managerOptions: []storage.Option{credentials},
+			options:        []storage.Option{NewClientCredentials("http://localhost/token", "client", "other")},
+			expect:         true,
+		},
+		{
+			description: "new token source",
+			options:     []storage.Option{NewBearer("abc")},
+			expect:      true,
+		},
+		{
+			description:    "changed basic auth",
+			managerOptions: []storage.Option{option.NewBasicAuth("user", "password")},
+			options:        []storage.Option{option.NewBasicAuth("user", "other")},
+			expect:         true,
+		},
+		{
+			description:    "forced auth",
+			managerOptions: []storage.Option{NewBearer("abc")},
+			options:        []storage.Option{NewBearer("abc"), option.NewAuth(true)},
+			expect:         true,
+		},
+	}
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		manager := newManager(useCase.managerOptions...)
+		assert.EqualValues(t, useCase.expect, manager.IsAuthChanged(ctx, "http://localhost", useCase.options), useCase.description)
+	}
+}
@@ -0,0 +1,240 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	etagHeader            = "ETag"
+	ifNoneMatchHeader     = "If-None-Match"
+	ifModifiedSinceHeader = "If-Modified-Since"
+	cacheEntryExt         = ".json"
+	cacheFileMode         = 0644
+)
+
+var errCacheIncomplete = errors.New("response body was not fully read")
+
+//CacheStore represents validator cache storage, i.e. afs.Service or any storage.Manager
+type CacheStore interface {
+	storage.Opener
+	storage.Uploader
+}
+
+//Cache represents opt-in conditional GET cache option, content with its ETag/Last-Modified validators is stored under BaseURL
+type Cache struct {
+	BaseURL string
+	Store   CacheStore
+}
+
+//cacheEntry represents cached content validators
+type cacheEntry struct {
+	URL          string
+	ETag         string    `json:",omitempty"`
+	LastModified time.Time `json:",omitempty"`
+}
+
+func (c *Cache) key(URL string) string {
+	hash := sha1.Sum([]byte(URL))
+	return url.Join(c.BaseURL, hex.EncodeToString(hash[:]))
+}
+
+//entry returns cache entry for supplied URL or nil
+func (c *Cache) entry(ctx context.Context, URL string) *cacheEntry {
+	reader, err := c.Store.OpenURL(ctx, c.key(URL)+cacheEntryExt)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = reader.Close() }()
+	entry := &cacheEntry{}
+	if err = json.NewDecoder(reader).Decode(entry); err != nil || entry.URL != URL {
+		return nil
+	}
+	return entry
+}
+
+//setValidators sets conditional request headers
+func (e *cacheEntry) setValidators(request *http.Request) {
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+	if e.ETag != "" {
+		request.Header.Set(ifNoneMatchHeader, e.ETag)
+	}
+	if !e.LastModified.IsZero() {
+		request.Header.Set(ifModifiedSinceHeader, e.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+//content returns cached content
+func (c *Cache) content(ctx context.Context, URL string) (io.ReadCloser, error) {
+	return c.Store.OpenURL(ctx, c.key(URL))
+}
+
+//putEntry stores content validators
+func (c *Cache) putEntry(ctx context.Context, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return c.Store.Upload(ctx, c.key(entry.URL)+cacheEntryExt, cacheFileMode, bytes.NewReader(data))
+}
+
+//invalidate overrides stored validators, so that partially rewritten content is never served
+func (c *Cache) invalidate(ctx context.Context, URL string) error {
+	return c.putEntry(ctx, &cacheEntry{URL: URL})
+}
+
+//newCacheEntry returns cache entry for response with validators or nil
+func newCacheEntry(URL string, response *http.Response) *cacheEntry {
+	entry := &cacheEntry{
+		URL:          URL,
+		ETag:         response.Header.Get(etagHeader),
+		LastModified: HeaderTime(response.Header, lastModifiedHeader, time.Time{}),
+	}
+	if entry.ETag == "" && entry.LastModified.IsZero() {
+		return nil
+	}
+	return entry
+}
+
+//cachingReader streams read content to the cache, validators are stored once the whole body has been read
+type cachingReader struct {
+	ctx    context.Context
+	cache  *Cache
+	entry  *cacheEntry
+	body   io.ReadCloser
+	writer *io.PipeWriter
+	done   chan error
+}
+
+func (r *cachingReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if r.entry == nil {
+		return n, err
+	}
+	if n > 0 {
+		if _, e := r.writer.Write(p[:n]); e != nil {
+			r.abort(e)
+			return n, err
+		}
+	}
+	if err == io.EOF {
+		_ = r.writer.Close()
+		e := <-r.done
+		if e == nil {
+			e = r.cache.putEntry(r.ctx, r.entry)
+		}
+		r.entry = nil
+		if e != nil {
+			return n, e
+		}
+	}
+	return n, err
+}
+
+//abort stops caching, content stored so far is never served as validators are not stored
+func (r *cachingReader) abort(err error) {
+	_ = r.writer.CloseWithError(err)
+	<-r.done
+	r.entry = nil
+}
+
+func (r *cachingReader) Close() error {
+	if r.entry != nil {
+		r.abort(errCacheIncomplete)
+	}
+	return r.body.Close()
+}
+
+//newCachingReader returns a reader streaming body to the cache
+func newCachingReader(ctx context.Context, cache *Cache, entry *cacheEntry, body io.ReadCloser) *cachingReader {
+	reader, writer := io.Pipe()
+	result := &cachingReader{ctx: ctx, cache: cache, entry: entry, body: body, writer: writer, done: make(chan error, 1)}
+	go func() {
+		err := cache.Store.Upload(ctx, cache.key(entry.URL), cacheFileMode, reader)
+		if err != nil {
+			_ = reader.CloseWithError(err)
+		} else {
+			_ = reader.CloseWithError(io.ErrClosedPipe)
+		}
+		result.done <- err
+	}()
+	return result
+}
+
+//openCached sends conditional GET request, not modified response content is served from the cache
+func (s *manager) openCached(ctx context.Context, URL string, cache *Cache, options []storage.Option) (io.ReadCloser, error) {
+	request, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	entry := cache.entry(ctx, URL)
+	if entry != nil {
+		entry.setValidators(request)
+	}
+	response, err := s.run(ctx, URL, request, options...)
+	if err != nil {
+		return nil, err
+	}
+	var status = &option.Status{}
+	option.Assign(options, &status)
+	status.Code = response.StatusCode
+	if response.StatusCode == http.StatusNotModified && entry != nil {
+		s.closeResponse(response)
+		if reader, err := cache.content(ctx, URL); err == nil {
+			return reader, nil
+		}
+		//cached content is missing, download it again
+		request.Header.Del(ifNoneMatchHeader)
+		request.Header.Del(ifModifiedSinceHeader)
+		if response, err = s.run(ctx, URL, request, options...); err != nil {
+			return nil, err
+		}
+		status.Code = response.StatusCode
+	}
+	if response.Body == nil {
+		return nil, fmt.Errorf("invalid status code: %v", response.StatusCode)
+	}
+	if !IsStatusOK(response) {
+		return response.Body, nil
+	}
+	stale := entry != nil
+	if entry = newCacheEntry(URL, response); entry == nil {
+		return response.Body, nil
+	}
+	if stale {
+		if err = cache.invalidate(ctx, URL); err != nil {
+			return response.Body, nil
+		}
+	}
+	return newCachingReader(ctx, cache, entry, response.Body), nil
+}
+
+//cache returns cache option or nil
+func (s *manager) cache(options []storage.Option) *Cache {
+	if len(s.options) > 0 {
+		options = append(s.options, options...)
+	}
+	cache := &Cache{}
+	if _, ok := option.Assign(options, &cache); !ok || cache == nil || cache.Store == nil {
+		return nil
+	}
+	return cache
+}
+
+//NewCache creates a conditional GET cache option
+func NewCache(baseURL string, store CacheStore) *Cache {
+	return &Cache{BaseURL: baseURL, Store: store}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestManager_OpenURL_Cache(t *testing.T) {
+	var content atomic.Value
+	var downloads int32
+	modified := time.Date(2019, 10, 20, 1, 2, 20, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		data := content.Load().(string)
+		if request.URL.Path == "/etag.json" {
+			writer.Header().Set(etagHeader, fmt.Sprintf(`"%x"`, len(data)))
+		}
+		recorder := &statusRecorder{ResponseWriter: writer}
+		http.ServeContent(recorder, request, request.URL.Path, modified, strings.NewReader(data))
+		if recorder.status != http.StatusNotModified {
+			atomic.AddInt32(&downloads, 1)
+		}
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description     string
+		URL             string
+		content         string
+		deleteCached    bool
+		partial         bool
+		expectDownloads int32
+		expectStatus    int
+	}{
+		{
+			description:     "etag first download",
+			URL:             server.URL + "/etag.json",
+			content:         `{"k":1}`,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:  "etag not modified",
+			URL:          server.URL + "/etag.json",
+			content:      `{"k":1}`,
+			expectStatus: http.StatusNotModified,
+		},
+		{
+			description:     "etag modified",
+			URL:             server.URL + "/etag.json",
+			content:         `{"k":10}`,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:     "last modified first download",
+			URL:             server.URL + "/modified.json",
+			content:         `{"k":2}`,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:  "last modified not modified",
+			URL:          server.URL + "/modified.json",
+			content:      `{"k":2}`,
+			expectStatus: http.StatusNotModified,
+		},
+		{
+			description:     "cached content missing",
+			URL:             server.URL + "/modified.json",
+			content:         `{"k":2}`,
+			deleteCached:    true,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:     "partially read content",
+			URL:             server.URL + "/partial.json",
+			content:         `{"k":3}`,
+			partial:         true,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:     "partially read content is not cached",
+			URL:             server.URL + "/partial.json",
+			content:         `{"k":3}`,
+			expectDownloads: 1,
+			expectStatus:    http.StatusOK,
+		},
+		{
+			description:  "fully read content is cached",
+			URL:          server.URL + "/partial.json",
+			content:      `{"k":3}`,
+			expectStatus: http.StatusNotModified,
+		},
+	}
+
+	ctx := context.Background()
+	store := mem.New()
+	cache := NewCache("mem://localhost/cache", store)
+	manager := newManager(cache)
+	for _, useCase := range useCases {
+		content.Store(useCase.content)
+		atomic.StoreInt32(&downloads, 0)
+		if useCase.deleteCached {
+			assert.Nil(t, store.Delete(ctx, cache.key(useCase.URL)), useCase.description)
+		}
+		status := option.NewStatus()
+		reader, err := manager.OpenURL(ctx, useCase.URL, status)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.partial {
+			_, err = reader.Read(make([]byte, 1))
+			assert.Nil(t, err, useCase.description)
+			assert.Nil(t, reader.Close(), useCase.description)
+			assert.EqualValues(t, useCase.expectDownloads, atomic.LoadInt32(&downloads), useCase.description)
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.content, string(data), useCase.description)
+		assert.EqualValues(t, useCase.expectStatus, status.Code, useCase.description)
+		assert.EqualValues(t, useCase.expectDownloads, atomic.LoadInt32(&downloads), useCase.description)
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
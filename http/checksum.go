@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/storage"
+	"net/http"
+	"strings"
+)
+
+const (
+	contentMD5Header = "Content-Md5"
+	googHashHeader   = "X-Goog-Hash"
+)
+
+//Checksum returns checksum advertised by Content-MD5 or X-Goog-Hash response headers, empty result if unknown,
+//ETag is not used as its format is server specific
+func (s *manager) Checksum(ctx context.Context, URL string, algorithm string, options ...storage.Option) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodHead, URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.run(ctx, URL, request, options...)
+	if err != nil {
+		return nil, err
+	}
+	s.closeResponse(response)
+	if !IsStatusOK(response) {
+		return nil, nil
+	}
+	return headerChecksum(response.Header, strings.ToLower(algorithm)), nil
+}
+
+func headerChecksum(header http.Header, algorithm string) []byte {
+	for _, value := range header.Values(googHashHeader) {
+		for _, pair := range strings.Split(value, ",") {
+			if index := strings.Index(pair, "="); index != -1 && strings.TrimSpace(pair[:index]) == algorithm {
+				if result, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pair[index+1:])); err == nil {
+					return result
+				}
+			}
+		}
+	}
+	if algorithm != checksum.MD5 {
+		return nil
+	}
+	if value := header.Get(contentMD5Header); value != "" {
+		if result, err := base64.StdEncoding.DecodeString(value); err == nil && len(result) == 16 {
+			return result
+		}
+	}
+	return nil
+}
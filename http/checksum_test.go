@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/checksum"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestManager_Checksum(t *testing.T) {
+	digest := md5.Sum([]byte("test"))
+	crc := []byte{1, 2, 3, 4}
+	headers := map[string]http.Header{
+		"/content-md5": {contentMD5Header: []string{base64.StdEncoding.EncodeToString(digest[:])}},
+		"/goog-hash":   {googHashHeader: []string{"crc32c=" + base64.StdEncoding.EncodeToString(crc) + ",md5=" + base64.StdEncoding.EncodeToString(digest[:])}},
+		"/etag":        {etagHeader: []string{`"` + hex.EncodeToString(digest[:]) + `"`}},
+		"/weak-etag":   {etagHeader: []string{`W/"` + hex.EncodeToString(digest[:]) + `"`}},
+		"/multipart":   {etagHeader: []string{`"` + hex.EncodeToString(digest[:]) + `-2"`}},
+		"/none":        {},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		header, ok := headers[request.URL.Path]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		for key, values := range header {
+			writer.Header()[key] = values
+		}
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description string
+		path        string
+		algorithm   string
+		expect      []byte
+	}{
+		{description: "content md5", path: "/content-md5", algorithm: checksum.MD5, expect: digest[:]},
+		{description: "goog hash md5", path: "/goog-hash", algorithm: checksum.MD5, expect: digest[:]},
+		{description: "goog hash crc32c", path: "/goog-hash", algorithm: checksum.CRC32C, expect: crc},
+		{description: "md5 like etag", path: "/etag", algorithm: checksum.MD5},
+		{description: "weak etag", path: "/weak-etag", algorithm: checksum.MD5},
+		{description: "multipart etag", path: "/multipart", algorithm: checksum.MD5},
+		{description: "no checksum headers", path: "/none", algorithm: checksum.MD5},
+		{description: "not found", path: "/missing", algorithm: checksum.MD5},
+	}
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		manager := newManager()
+		actual, err := manager.Checksum(ctx, server.URL+useCase.path, useCase.algorithm)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
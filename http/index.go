@@ -0,0 +1,283 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"golang.org/x/net/html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	jsonDirectoryType = "directory"
+	jsonFileType      = "file"
+	indexPeekSize     = 512
+	//htmlIndexPeekSize represents max html prefix size read to detect directory listing markers
+	htmlIndexPeekSize = 4096
+)
+
+var indexTitlePrefixes = []string{"index of", "directory listing for"}
+
+var jsonIndexPrefix = regexp.MustCompile(`^\s*\[\s*(\]|\{\s*"name"\s*:)`)
+
+var indexTimeLayouts = []string{
+	"02-Jan-2006 15:04",
+	"02-Jan-2006 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-Jan-02 15:04",
+	"2006-Jan-02 15:04:05",
+}
+
+//indexEntry represents directory index entry
+type indexEntry struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Mtime    string `json:"mtime"`
+	Size     int64  `json:"size"`
+	isDir    bool
+	modified time.Time
+}
+
+//readIndex returns directory index entries if response represents an autoindex page (html or nginx json)
+func readIndex(response *http.Response) ([]*indexEntry, bool) {
+	if response.Body == nil || response.Request == nil {
+		return nil, false
+	}
+	mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		return readJSONIndex(response.Body)
+	case "text/html":
+		return readHTMLIndex(response.Body, response.Request.URL)
+	}
+	return nil, false
+}
+
+//readJSONIndex reads nginx autoindex_format json listing
+func readJSONIndex(reader io.Reader) ([]*indexEntry, bool) {
+	bufReader := bufio.NewReaderSize(reader, indexPeekSize)
+	prefix, _ := bufReader.Peek(indexPeekSize)
+	if !jsonIndexPrefix.Match(prefix) {
+		return nil, false
+	}
+	var entries = make([]*indexEntry, 0)
+	if err := json.NewDecoder(bufReader).Decode(&entries); err != nil {
+		return nil, false
+	}
+	for _, entry := range entries {
+		if entry.Name == "" || (entry.Type != jsonFileType && entry.Type != jsonDirectoryType) {
+			return nil, false
+		}
+		entry.isDir = entry.Type == jsonDirectoryType
+		entry.modified, _ = ParseHTTPDate(entry.Mtime)
+	}
+	return entries, true
+}
+
+//readHTMLIndex reads Apache, nginx, python http.server or go http.FileServer directory listing page,
+//only page prefix is read unless it contains directory listing markers
+func readHTMLIndex(reader io.Reader, dirURL *neturl.URL) ([]*indexEntry, bool) {
+	prefix, err := ioutil.ReadAll(io.LimitReader(reader, htmlIndexPeekSize))
+	if err != nil {
+		return nil, false
+	}
+	prefixDocument, err := html.Parse(bytes.NewReader(prefix))
+	if err != nil || !isHTMLIndex(prefixDocument, dirURL) {
+		return nil, false
+	}
+	document, err := html.Parse(io.MultiReader(bytes.NewReader(prefix), reader))
+	if err != nil {
+		return nil, false
+	}
+	dirPath := dirURL.Path
+	if !strings.HasSuffix(dirPath, "/") {
+		dirPath += "/"
+	}
+	var result = make([]*indexEntry, 0)
+	var indexed = make(map[string]bool)
+	var anchors = make([]*html.Node, 0)
+	collectNodes(document, "a", &anchors)
+	for _, anchor := range anchors {
+		href := attribute(anchor, "href")
+		name, isDir, ok := indexEntryName(dirURL, dirPath, href)
+		if !ok || indexed[name] {
+			continue
+		}
+		indexed[name] = true
+		entry := &indexEntry{Name: name, isDir: isDir}
+		entry.modified, entry.Size = entryDetails(followingText(anchor), isDir)
+		result = append(result, entry)
+	}
+	return result, true
+}
+
+//isHTMLIndex returns true if document title or heading looks like directory listing,
+//or document is a title-less pre listing served for a directory path (go http.FileServer)
+func isHTMLIndex(document *html.Node, dirURL *neturl.URL) bool {
+	for _, tag := range []string{"title", "h1"} {
+		var nodes = make([]*html.Node, 0)
+		collectNodes(document, tag, &nodes)
+		for _, node := range nodes {
+			title := strings.ToLower(strings.TrimSpace(textContent(node)))
+			for _, prefix := range indexTitlePrefixes {
+				if strings.HasPrefix(title, prefix) {
+					return true
+				}
+			}
+		}
+		if tag == "title" && len(nodes) > 0 {
+			return false
+		}
+	}
+	if !strings.HasSuffix(dirURL.Path, "/") {
+		return false
+	}
+	var pre = make([]*html.Node, 0)
+	collectNodes(document, "pre", &pre)
+	return len(pre) > 0
+}
+
+//indexEntryName returns direct child name for supplied href or false
+func indexEntryName(dirURL *neturl.URL, dirPath, href string) (string, bool, bool) {
+	if href == "" || strings.HasPrefix(href, "?") || strings.HasPrefix(href, "#") {
+		return "", false, false
+	}
+	ref, err := neturl.Parse(href)
+	if err != nil {
+		return "", false, false
+	}
+	resolved := dirURL.ResolveReference(ref)
+	if resolved.Host != dirURL.Host || !strings.HasPrefix(resolved.Path, dirPath) {
+		return "", false, false
+	}
+	relative := resolved.Path[len(dirPath):]
+	isDir := strings.HasSuffix(relative, "/")
+	relative = strings.TrimSuffix(relative, "/")
+	if relative == "" || strings.Contains(relative, "/") {
+		return "", false, false
+	}
+	return relative, isDir, true
+}
+
+//entryDetails parses modification time and size from listing text following an entry anchor
+func entryDetails(text string, isDir bool) (time.Time, int64) {
+	var modified time.Time
+	var size int64
+	fields := strings.Fields(text)
+	for i := 0; i+1 < len(fields); i++ {
+		candidate := fields[i] + " " + fields[i+1]
+		for _, layout := range indexTimeLayouts {
+			if ts, err := time.Parse(layout, candidate); err == nil {
+				modified = ts
+				fields = fields[i+2:]
+				break
+			}
+		}
+		if !modified.IsZero() {
+			break
+		}
+	}
+	if isDir || modified.IsZero() || len(fields) == 0 {
+		return modified, size
+	}
+	size, _ = parseIndexSize(fields[0])
+	return modified, size
+}
+
+//parseIndexSize parses exact or human readable (i.e. 1.2K, 3M) size
+func parseIndexSize(value string) (int64, bool) {
+	if value == "" || value == "-" {
+		return 0, false
+	}
+	multiplier := 1.0
+	switch strings.ToUpper(value[len(value)-1:]) {
+	case "K":
+		multiplier = 1 << 10
+	case "M":
+		multiplier = 1 << 20
+	case "G":
+		multiplier = 1 << 30
+	case "T":
+		multiplier = 1 << 40
+	}
+	if multiplier > 1 {
+		value = value[:len(value)-1]
+	}
+	size, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(size * multiplier), true
+}
+
+//followingText returns text between supplied anchor and the next anchor within the same table row or block
+func followingText(anchor *html.Node) string {
+	var builder strings.Builder
+	node := anchor
+	for node != nil {
+		for sibling := node.NextSibling; sibling != nil; sibling = sibling.NextSibling {
+			if sibling.Type == html.ElementNode && sibling.Data == "a" {
+				return builder.String()
+			}
+			if sibling.Type == html.ElementNode && hasDescendant(sibling, "a") {
+				return builder.String()
+			}
+			text := textContent(sibling)
+			if index := strings.Index(text, "\n"); index != -1 && sibling.Type == html.TextNode {
+				builder.WriteString(text[:index])
+				return builder.String()
+			}
+			builder.WriteString(" ")
+			builder.WriteString(text)
+		}
+		node = node.Parent
+		if node == nil || node.Type != html.ElementNode || node.Data == "tr" || node.Data == "pre" || node.Data == "li" || node.Data == "body" {
+			break
+		}
+	}
+	return builder.String()
+}
+
+func hasDescendant(node *html.Node, tag string) bool {
+	var nodes = make([]*html.Node, 0)
+	collectNodes(node, tag, &nodes)
+	return len(nodes) > 0
+}
+
+func collectNodes(node *html.Node, tag string, result *[]*html.Node) {
+	if node.Type == html.ElementNode && node.Data == tag {
+		*result = append(*result, node)
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		collectNodes(child, tag, result)
+	}
+}
+
+func textContent(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+	var builder strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(textContent(child))
+	}
+	return builder.String()
+}
+
+func attribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
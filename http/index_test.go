@@ -0,0 +1,223 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const apacheIndex = `<!DOCTYPE HTML PUBLIC "-//W3C//DTD HTML 3.2 Final//EN">
+<html>
+ <head>
+  <title>Index of /apache</title>
+ </head>
+ <body>
+<h1>Index of /apache</h1>
+  <table>
+   <tr><th valign="top"><img src="/icons/blank.gif" alt="[ICO]"></th><th><a href="?C=N;O=D">Name</a></th><th><a href="?C=M;O=A">Last modified</a></th><th><a href="?C=S;O=A">Size</a></th></tr>
+   <tr><td valign="top"><img src="/icons/back.gif" alt="[PARENTDIR]"></td><td><a href="/">Parent Directory</a></td><td>&nbsp;</td><td align="right">  - </td></tr>
+   <tr><td valign="top"><img src="/icons/text.gif" alt="[TXT]"></td><td><a href="asset1.txt">asset1.txt</a></td><td align="right">2019-10-20 01:02  </td><td align="right">1.5K</td></tr>
+   <tr><td valign="top"><img src="/icons/folder.gif" alt="[DIR]"></td><td><a href="sub/">sub/</a></td><td align="right">2019-10-21 03:04  </td><td align="right">  - </td></tr>
+  </table>
+</body></html>`
+
+const nginxIndex = `<html>
+<head><title>Index of /nginx/</title></head>
+<body>
+<h1>Index of /nginx/</h1><hr><pre><a href="../">../</a>
+<a href="sub/">sub/</a>                                               21-Oct-2019 03:04                   -
+<a href="asset%201.txt">asset 1.txt</a>                                        20-Oct-2019 01:02                 120
+</pre><hr></body>
+</html>`
+
+const pythonIndex = `<!DOCTYPE HTML>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Directory listing for /python/</title>
+</head>
+<body>
+<h1>Directory listing for /python/</h1>
+<hr>
+<ul>
+<li><a href="asset1.txt">asset1.txt</a></li>
+<li><a href="sub/">sub/</a></li>
+</ul>
+<hr>
+</body>
+</html>`
+
+const nginxJSONIndex = `[
+{ "name":"sub", "type":"directory", "mtime":"Mon, 21 Oct 2019 03:04:00 GMT" },
+{ "name":"asset1.txt", "type":"file", "mtime":"Sun, 20 Oct 2019 01:02:00 GMT", "size":120 }
+]`
+
+const htmlPage = `<html><head><title>Home</title></head><body><a href="asset1.txt">asset1.txt</a></body></html>`
+
+func TestManager_List_Index(t *testing.T) {
+	pages := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"/apache/":     {"text/html;charset=ISO-8859-1", apacheIndex},
+		"/nginx/":      {"text/html", nginxIndex},
+		"/python/":     {"text/html; charset=utf-8", pythonIndex},
+		"/json/":       {"application/json", nginxJSONIndex},
+		"/page.html":   {"text/html", htmlPage},
+		"/config.json": {"application/json", `{"name":"config"}`},
+		"/users.json":  {"application/json", `[{"name":"bob", "type":"user"}]`},
+		"/nested/":     {"text/html", nginxIndex},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		page, ok := pages[request.URL.Path]
+		if !ok {
+			http.NotFound(writer, request)
+			return
+		}
+		writer.Header().Set("Content-Type", page.contentType)
+		_, _ = writer.Write([]byte(page.body))
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description string
+		URL         string
+		options     []storage.Option
+		expectDir   bool
+		expect      map[string]indexEntry
+	}{
+		{
+			description: "apache fancy index",
+			URL:         server.URL + "/apache/",
+			expectDir:   true,
+			expect: map[string]indexEntry{
+				"asset1.txt": {Size: 1536, modified: time.Date(2019, 10, 20, 1, 2, 0, 0, time.UTC)},
+				"sub":        {isDir: true, modified: time.Date(2019, 10, 21, 3, 4, 0, 0, time.UTC)},
+			},
+		},
+		{
+			description: "nginx autoindex",
+			URL:         server.URL + "/nginx/",
+			expectDir:   true,
+			expect: map[string]indexEntry{
+				"asset 1.txt": {Size: 120, modified: time.Date(2019, 10, 20, 1, 2, 0, 0, time.UTC)},
+				"sub":         {isDir: true, modified: time.Date(2019, 10, 21, 3, 4, 0, 0, time.UTC)},
+			},
+		},
+		{
+			description: "python http.server",
+			URL:         server.URL + "/python/",
+			expectDir:   true,
+			expect: map[string]indexEntry{
+				"asset1.txt": {},
+				"sub":        {isDir: true},
+			},
+		},
+		{
+			description: "nginx json autoindex",
+			URL:         server.URL + "/json/",
+			expectDir:   true,
+			expect: map[string]indexEntry{
+				"asset1.txt": {Size: 120, modified: time.Date(2019, 10, 20, 1, 2, 0, 0, time.UTC)},
+				"sub":        {isDir: true, modified: time.Date(2019, 10, 21, 3, 4, 0, 0, time.UTC)},
+			},
+		},
+		{
+			description: "paged index",
+			URL:         server.URL + "/nested/",
+			options:     []storage.Option{*option.NewPage(0, 1)},
+			expectDir:   true,
+			expect: map[string]indexEntry{
+				"sub": {isDir: true, modified: time.Date(2019, 10, 21, 3, 4, 0, 0, time.UTC)},
+			},
+		},
+		{
+			description: "regular html page",
+			URL:         url.Join(server.URL, "page.html"),
+			expect:      map[string]indexEntry{},
+		},
+		{
+			description: "regular json document",
+			URL:         url.Join(server.URL, "config.json"),
+			expect:      map[string]indexEntry{},
+		},
+		{
+			description: "json array with unknown entry type",
+			URL:         url.Join(server.URL, "users.json"),
+			expect:      map[string]indexEntry{},
+		},
+	}
+
+	ctx := context.Background()
+	manager := New()
+	for _, useCase := range useCases {
+		objects, err := manager.List(ctx, useCase.URL, useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if !assert.EqualValues(t, len(useCase.expect)+1, len(objects), useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expectDir, objects[0].IsDir(), useCase.description)
+		for _, object := range objects[1:] {
+			expect, ok := useCase.expect[object.Name()]
+			if !assert.True(t, ok, useCase.description+" "+object.Name()) {
+				continue
+			}
+			assert.EqualValues(t, expect.isDir, object.IsDir(), useCase.description+" "+object.Name())
+			assert.EqualValues(t, expect.Size, object.Size(), useCase.description+" "+object.Name())
+			assert.True(t, strings.HasSuffix(object.URL(), "/"+object.Name()), useCase.description+" "+object.Name())
+			if !expect.modified.IsZero() {
+				assert.EqualValues(t, expect.modified.Unix(), object.ModTime().Unix(), useCase.description+" "+object.Name())
+			}
+		}
+	}
+}
+
+func TestReadHTMLIndex_Prefix(t *testing.T) {
+	var useCases = []struct {
+		description string
+		body        string
+		maxRead     int
+		expectIndex bool
+	}{
+		{
+			description: "regular page reads prefix only",
+			body:        htmlPage + strings.Repeat("<p>content</p>", 100000),
+			maxRead:     htmlIndexPeekSize,
+		},
+		{
+			description: "index page reads whole body",
+			body:        nginxIndex,
+			maxRead:     len(nginxIndex),
+			expectIndex: true,
+		},
+	}
+	dirURL, _ := neturl.Parse("http://localhost/nginx/")
+	for _, useCase := range useCases {
+		reader := &countingReader{Reader: strings.NewReader(useCase.body)}
+		_, ok := readHTMLIndex(reader, dirURL)
+		assert.EqualValues(t, useCase.expectIndex, ok, useCase.description)
+		assert.True(t, reader.count <= useCase.maxRead, useCase.description)
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	count int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += n
+	return n, err
+}
@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
 	"github.com/viant/afs/url"
 	"net/http"
 	"path"
+	"strings"
 	"time"
 )
 
 const lastModifiedHeader = "Last-Modified"
 
 var assetMode, _ = file.NewMode("-rw-r--r--")
+var dirMode, _ = file.NewMode("drwxr-xr-x")
 
+//List returns an asset for supplied URL, directory index pages (Apache, nginx html/json, python http.server, go http.FileServer)
+//are listed as directory followed by its entries
 func (s *manager) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
 	request, err := http.NewRequest(http.MethodGet, URL, nil)
 	if err != nil {
@@ -30,11 +35,43 @@ func (s *manager) List(ctx context.Context, URL string, options ...storage.Optio
 		return nil, fmt.Errorf("resource not found, statusCode: %v, url: %v", response.StatusCode, URL)
 	}
 	_, URLPath := url.Base(URL, Scheme)
-	_, name := path.Split(URLPath)
+	_, name := path.Split(strings.TrimRight(URLPath, "/"))
 	modified := HeaderTime(response.Header, lastModifiedHeader, time.Now())
+	if entries, ok := readIndex(response); ok {
+		return s.indexObjects(URL, name, modified, entries, options), nil
+	}
 	info := file.NewInfo(name, response.ContentLength, assetMode, modified, false)
 	asset := object.New(URL, info, response)
 	return []storage.Object{
 		asset,
 	}, nil
 }
+
+func (s *manager) indexObjects(URL, name string, modified time.Time, entries []*indexEntry, options []storage.Option) []storage.Object {
+	match, page := option.GetListOptions(options)
+	_, URLPath := url.Base(URL, Scheme)
+	var result = make([]storage.Object, 0, len(entries)+1)
+	result = append(result, object.New(URL, file.NewInfo(name, 0, dirMode, modified, true), nil))
+	for _, entry := range entries {
+		mode := assetMode
+		if entry.isDir {
+			mode = dirMode
+		}
+		if entry.modified.IsZero() {
+			entry.modified = modified
+		}
+		info := file.NewInfo(entry.Name, entry.Size, mode, entry.modified, entry.isDir)
+		if !match(URLPath, info) {
+			continue
+		}
+		page.Increment()
+		if page.ShallSkip() {
+			continue
+		}
+		result = append(result, object.New(url.Join(URL, entry.Name), info, nil))
+		if page.HasReachedLimit() {
+			break
+		}
+	}
+	return result
+}
@@ -10,6 +10,8 @@ type manager struct {
 	client         *http.Client
 	mux            sync.Mutex
 	baseURLClients map[string]*http.Client
+	proxyClients   map[string]*http.Client
+	netrc          netrc
 	options        []storage.Option
 }
 
@@ -31,6 +33,9 @@ func (s *manager) Close() error {
 	for _, client := range s.baseURLClients {
 		CloseIdleConnections(client)
 	}
+	for _, client := range s.proxyClients {
+		CloseIdleConnections(client)
+	}
 	return nil
 }
 
@@ -43,6 +48,7 @@ func newManager(options ...storage.Option) *manager {
 	return &manager{
 		options:        options,
 		baseURLClients: make(map[string]*http.Client),
+		proxyClients:   make(map[string]*http.Client),
 	}
 }
 
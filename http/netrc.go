@@ -0,0 +1,100 @@
+package http
+
+import (
+	"github.com/viant/afs/option"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const netrcEnvKey = "NETRC"
+
+//netrc represents .netrc machine credentials
+type netrc map[string]option.BasicAuth
+
+//netrcLocation returns $NETRC or the user home .netrc (_netrc on windows) location
+func netrcLocation() string {
+	if location := os.Getenv(netrcEnvKey); location != "" {
+		return location
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := ".netrc"
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+	return filepath.Join(home, name)
+}
+
+//parseNetrc parses machine entries, default entry and macro definitions are ignored
+func parseNetrc(content string) netrc {
+	var result = netrc{}
+	var machine, login, password string
+	flush := func() {
+		if machine != "" && (login != "" || password != "") {
+			result[machine] = option.NewBasicAuth(login, password)
+		}
+		machine, login, password = "", "", ""
+	}
+	inMacro := false
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if inMacro {
+			//macro definition ends with an empty line
+			inMacro = len(fields) > 0
+			continue
+		}
+		for i := 0; i < len(fields); i++ {
+			switch fields[i] {
+			case "machine", "default", "macdef":
+				flush()
+				if fields[i] == "macdef" {
+					inMacro = true
+					i = len(fields)
+					continue
+				}
+				if fields[i] == "machine" && i+1 < len(fields) {
+					i++
+					machine = fields[i]
+				}
+			case "login":
+				if i+1 < len(fields) {
+					i++
+					login = fields[i]
+				}
+			case "password":
+				if i+1 < len(fields) {
+					i++
+					password = fields[i]
+				}
+			}
+		}
+	}
+	flush()
+	return result
+}
+
+//netrcAuth returns .netrc credentials for supplied host or nil
+func (s *manager) netrcAuth(host string) option.BasicAuth {
+	s.mux.Lock()
+	if s.netrc == nil {
+		s.netrc = netrc{}
+		if location := netrcLocation(); location != "" {
+			if data, err := ioutil.ReadFile(location); err == nil {
+				s.netrc = parseNetrc(string(data))
+			}
+		}
+	}
+	s.mux.Unlock()
+	if auth, ok := s.netrc[host]; ok {
+		return auth
+	}
+	if index := strings.LastIndex(host, ":"); index != -1 {
+		return s.netrc[host[:index]]
+	}
+	return nil
+}
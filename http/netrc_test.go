@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseNetrc(t *testing.T) {
+	var useCases = []struct {
+		description string
+		content     string
+		expect      map[string][]string
+	}{
+		{
+			description: "multi line entries",
+			content: `machine example.com
+  login user1
+  password secret1
+
+machine api.example.com login user2 password secret2
+`,
+			expect: map[string][]string{
+				"example.com":     {"user1", "secret1"},
+				"api.example.com": {"user2", "secret2"},
+			},
+		},
+		{
+			description: "default entry and macro",
+			content: `machine example.com login user1 password secret1
+macdef init
+cd /pub
+machine fake.com login fake password fake
+
+default login anonymous password guest
+machine other.com login user3 password secret3`,
+			expect: map[string][]string{
+				"example.com": {"user1", "secret1"},
+				"other.com":   {"user3", "secret3"},
+			},
+		},
+	}
+	for _, useCase := range useCases {
+		actual := parseNetrc(useCase.content)
+		assert.EqualValues(t, len(useCase.expect), len(actual), useCase.description)
+		for machine, expect := range useCase.expect {
+			auth, ok := actual[machine]
+			if !assert.True(t, ok, useCase.description+" "+machine) {
+				continue
+			}
+			user, password := auth.Credentials()
+			assert.EqualValues(t, expect, []string{user, password}, useCase.description+" "+machine)
+		}
+	}
+}
+
+func TestManager_NetrcAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		user, password, ok := request.BasicAuth()
+		if !ok {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = writer.Write([]byte(user + ":" + password))
+	}))
+	defer server.Close()
+	location := filepath.Join(t.TempDir(), ".netrc")
+	assert.Nil(t, ioutil.WriteFile(location, []byte("machine 127.0.0.1 login netrc password secret\n"), 0600))
+	t.Setenv(netrcEnvKey, location)
+
+	var useCases = []struct {
+		description string
+		options     []storage.Option
+		expect      string
+	}{
+		{
+			description: "netrc fallback",
+			expect:      "netrc:secret",
+		},
+		{
+			description: "basic auth precedence",
+			options:     []storage.Option{option.NewBasicAuth("user", "password")},
+			expect:      "user:password",
+		},
+	}
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		manager := newManager()
+		reader, err := manager.OpenURL(ctx, server.URL+"/asset.txt", useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, _ := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+	}
+}
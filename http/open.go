@@ -20,8 +20,39 @@ func (s *manager) OpenURL(ctx context.Context, URL string, options ...storage.Op
 	if _, ok := option.Assign(options, &method); !ok {
 		method = http.MethodGet
 	}
+	if err := s.preSign(URL, options); err != nil {
+		return nil, err
+	}
 	var reader io.Reader
 	option.Assign(options, &reader)
+	isGet := reader == nil && method == http.MethodGet
+	stream := &option.Stream{}
+	if _, ok := option.Assign(options, &stream); ok && isGet && stream != nil && stream.PartSize > 0 {
+		return s.openStream(ctx, URL, stream, options)
+	}
+	byteRange := &option.Range{}
+	if _, ok := option.Assign(options, &byteRange); !ok || !isGet {
+		byteRange = nil
+	}
+	if cache := s.cache(options); cache != nil && isGet && byteRange == nil {
+		return s.openCached(ctx, URL, cache, options)
+	}
+	var status = &option.Status{}
+	option.Assign(options, &status)
+	if byteRange != nil {
+		response, err := s.getRange(ctx, URL, byteRange.Offset, byteRange.Length, "", options)
+		if err != nil {
+			return nil, err
+		}
+		status.Code = response.StatusCode
+		if response.Body == nil {
+			return nil, fmt.Errorf("invalid status code: %v", response.StatusCode)
+		}
+		if response.StatusCode != http.StatusPartialContent {
+			return response.Body, nil
+		}
+		return s.resumable(ctx, URL, response, byteRange.Offset, options), nil
+	}
 	request, err := http.NewRequest(string(method), URL, reader)
 	if err != nil {
 		return nil, err
@@ -30,11 +61,12 @@ func (s *manager) OpenURL(ctx context.Context, URL string, options ...storage.Op
 	if err != nil {
 		return nil, err
 	}
-	var status  = &option.Status{}
-	option.Assign(options, &status)
 	status.Code = response.StatusCode
-	if response.Body != nil {
-		return response.Body, nil
+	if response.Body == nil {
+		return nil, fmt.Errorf("invalid status code: %v", response.StatusCode)
+	}
+	if isGet && response.StatusCode == http.StatusOK {
+		return s.resumable(ctx, URL, response, 0, options), nil
 	}
-	return nil, fmt.Errorf("invalid status code: %v", response.StatusCode)
+	return response.Body, nil
 }
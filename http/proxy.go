@@ -0,0 +1,101 @@
+package http
+
+import (
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"time"
+)
+
+const defaultProxyTimeoutMs = 30000
+
+//proxy returns proxy option or nil, proxy is ignored when custom ClientProvider is used
+func (s *manager) proxy(options []storage.Option) *option.Proxy {
+	if len(s.options) > 0 {
+		options = append(s.options, options...)
+	}
+	var clientProvider ClientProvider
+	proxy := &option.Proxy{}
+	option.Assign(options, &clientProvider, &proxy)
+	if clientProvider != nil || proxy == nil || proxy.URL == "" {
+		return nil
+	}
+	return proxy
+}
+
+//doWithProxy sends request through the proxy, with Fallback enabled failed request is resent with supplied client
+func (s *manager) doWithProxy(client *http.Client, request *http.Request, proxy *option.Proxy) (*http.Response, error) {
+	proxyClient, err := s.getProxyClient(proxy)
+	if err != nil {
+		return nil, err
+	}
+	fallback, canFallback := replayRequest(request)
+	response, err := proxyClient.Do(request)
+	if !proxy.Fallback || !canFallback || !isProxyFailure(response, err) {
+		return response, err
+	}
+	if response != nil {
+		s.closeResponse(response)
+	}
+	return client.Do(fallback)
+}
+
+//getProxyClient returns a client routing requests through supplied proxy
+func (s *manager) getProxyClient(proxy *option.Proxy) (*http.Client, error) {
+	key := fmt.Sprintf("%v:%v", proxy.URL, proxy.TimeoutMs)
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if client, ok := s.proxyClients[key]; ok {
+		return client, nil
+	}
+	proxyURL, err := neturl.Parse(proxy.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v, %w", proxy.URL, err)
+	}
+	timeoutMs := proxy.TimeoutMs
+	if timeoutMs == 0 {
+		timeoutMs = defaultProxyTimeoutMs
+	}
+	timeout := time.Duration(timeoutMs) * time.Millisecond
+	transport := &http.Transport{
+		Proxy: http.ProxyURL(proxyURL),
+		DialContext: (&net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	client := &http.Client{Transport: transport}
+	s.proxyClients[key] = client
+	return client, nil
+}
+
+//isProxyFailure returns true if request failed due to proxy
+func isProxyFailure(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return response.StatusCode == http.StatusProxyAuthRequired
+}
+
+//replayRequest returns a request copy that can be resent
+func replayRequest(request *http.Request) (*http.Request, bool) {
+	result := request.Clone(request.Context())
+	if request.Body == nil || request.Body == http.NoBody {
+		return result, true
+	}
+	if request.GetBody == nil {
+		return nil, false
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	result.Body = body
+	return result, true
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestManager_Proxy(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPut {
+			body, _ := ioutil.ReadAll(request.Body)
+			_, _ = writer.Write(body)
+			return
+		}
+		_, _ = writer.Write([]byte("origin"))
+	}))
+	defer origin.Close()
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		request.RequestURI = ""
+		response, err := http.DefaultTransport.RoundTrip(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer response.Body.Close()
+		writer.WriteHeader(response.StatusCode)
+		data, _ := ioutil.ReadAll(response.Body)
+		_, _ = writer.Write(data)
+	}))
+	defer proxy.Close()
+
+	authProxy := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusProxyAuthRequired)
+	}))
+	defer authProxy.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	deadProxyURL := "http://" + listener.Addr().String()
+	_ = listener.Close()
+
+	var useCases = []struct {
+		description string
+		proxy       *option.Proxy
+		method      string
+		body        string
+		expect      string
+		expectProxy int32
+		hasError    bool
+	}{
+		{
+			description: "request via proxy",
+			proxy:       option.NewProxy(proxy.URL, 1000, false),
+			expect:      "origin",
+			expectProxy: 1,
+		},
+		{
+			description: "unavailable proxy with fallback",
+			proxy:       option.NewProxy(deadProxyURL, 1000, true),
+			expect:      "origin",
+		},
+		{
+			description: "unavailable proxy with fallback with body",
+			proxy:       option.NewProxy(deadProxyURL, 1000, true),
+			method:      http.MethodPut,
+			body:        "test",
+			expect:      "test",
+		},
+		{
+			description: "proxy auth required with fallback",
+			proxy:       option.NewProxy(authProxy.URL, 1000, true),
+			expect:      "origin",
+		},
+		{
+			description: "unavailable proxy without fallback",
+			proxy:       option.NewProxy(deadProxyURL, 1000, false),
+			hasError:    true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		atomic.StoreInt32(&proxied, 0)
+		manager := newManager()
+		method := useCase.method
+		if method == "" {
+			method = http.MethodGet
+		}
+		options := []storage.Option{useCase.proxy, option.HTTPMethod(method)}
+		if useCase.body != "" {
+			options = append(options, strings.NewReader(useCase.body))
+		}
+		reader, err := manager.OpenURL(ctx, origin.URL+"/asset.txt", options...)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+		assert.EqualValues(t, useCase.expectProxy, atomic.LoadInt32(&proxied), useCase.description)
+	}
+}
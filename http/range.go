@@ -0,0 +1,232 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/base"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	acceptRangesHeader = "Accept-Ranges"
+	contentRangeHeader = "Content-Range"
+	ifRangeHeader      = "If-Range"
+	maxResumeRetries   = 3
+)
+
+//rangeHeader returns range header value for supplied offset and length, zero length means till the end
+func rangeHeader(offset, length int64) string {
+	if length <= 0 {
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+	return fmt.Sprintf(base.RangeHeaderTmpl, offset, offset+length-1)
+}
+
+//contentSize returns total content size from Content-Range or Content-Length header
+func contentSize(response *http.Response) int64 {
+	if contentRange := response.Header.Get(contentRangeHeader); contentRange != "" {
+		if index := strings.LastIndex(contentRange, "/"); index != -1 {
+			if size, err := strconv.ParseInt(contentRange[index+1:], 10, 64); err == nil {
+				return size
+			}
+		}
+	}
+	return response.ContentLength
+}
+
+//validator returns If-Range validator, only strong ETag or Last-Modified can be used
+func validator(response *http.Response) string {
+	if etag := response.Header.Get(etagHeader); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return response.Header.Get(lastModifiedHeader)
+}
+
+//getRange sends ranged GET request, when server ignores range, the full content body is skipped and limited to the range
+func (s *manager) getRange(ctx context.Context, URL string, offset, length int64, ifRange string, options []storage.Option) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header = http.Header{}
+	request.Header.Set(base.RangeHeader, rangeHeader(offset, length))
+	if ifRange != "" {
+		request.Header.Set(ifRangeHeader, ifRange)
+	}
+	response, err := s.run(ctx, URL, request, options...)
+	if err != nil {
+		return nil, err
+	}
+	switch response.StatusCode {
+	case http.StatusPartialContent:
+		return response, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		s.closeResponse(response)
+		response.Body = ioutil.NopCloser(strings.NewReader(""))
+		return response, nil
+	}
+	if !IsStatusOK(response) || response.Body == nil {
+		return response, nil
+	}
+	if offset > 0 {
+		if _, err = io.CopyN(ioutil.Discard, response.Body, offset); err != nil && err != io.EOF {
+			s.closeResponse(response)
+			return nil, err
+		}
+	}
+	if length > 0 {
+		response.Body = &limitedReadCloser{Reader: io.LimitReader(response.Body, length), Closer: response.Body}
+	}
+	return response, nil
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+//resumableReader re-requests remaining content from the last received byte when transfer gets interrupted
+type resumableReader struct {
+	ctx       context.Context
+	manager   *manager
+	URL       string
+	options   []storage.Option
+	body      io.ReadCloser
+	offset    int64
+	end       int64
+	validator string
+	retries   int
+}
+
+func (r *resumableReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == io.EOF && r.offset < r.end {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == nil || err == io.EOF || r.retries >= maxResumeRetries {
+		return n, err
+	}
+	r.retries++
+	_ = r.body.Close()
+	response, rErr := r.manager.getRange(r.ctx, r.URL, r.offset, r.end-r.offset, r.validator, r.options)
+	if rErr != nil || response.StatusCode != http.StatusPartialContent {
+		if rErr == nil {
+			r.manager.closeResponse(response)
+		}
+		r.body = ioutil.NopCloser(strings.NewReader(""))
+		r.retries = maxResumeRetries
+		return n, err
+	}
+	r.body = response.Body
+	if n > 0 {
+		return n, nil
+	}
+	return r.Read(p)
+}
+
+func (r *resumableReader) Close() error {
+	return r.body.Close()
+}
+
+//resumable wraps response body with resumable reader if server supports ranges and content size is known
+func (s *manager) resumable(ctx context.Context, URL string, response *http.Response, offset int64, options []storage.Option) io.ReadCloser {
+	if response.StatusCode != http.StatusPartialContent && response.Header.Get(acceptRangesHeader) != "bytes" {
+		return response.Body
+	}
+	if response.ContentLength <= 0 {
+		return response.Body
+	}
+	return &resumableReader{
+		ctx:       ctx,
+		manager:   s,
+		URL:       URL,
+		options:   options,
+		body:      response.Body,
+		offset:    offset,
+		end:       offset + response.ContentLength,
+		validator: validator(response),
+	}
+}
+
+//rangeReader represents a ranged request based io.ReadSeeker, used by base stream reader
+type rangeReader struct {
+	ctx     context.Context
+	manager *manager
+	URL     string
+	options []storage.Option
+	size    int64
+	offset  int64
+}
+
+//Read reads exactly len(p) bytes with a ranged request unless the end of content is reached
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	length := int64(len(p))
+	if r.offset+length > r.size {
+		length = r.size - r.offset
+	}
+	response, err := r.manager.getRange(r.ctx, r.URL, r.offset, length, "", r.options)
+	if err != nil {
+		return 0, err
+	}
+	defer r.manager.closeResponse(response)
+	if !IsStatusOK(response) {
+		return 0, fmt.Errorf("failed to read range %v, statusCode: %v, url: %v", rangeHeader(r.offset, length), response.StatusCode, r.URL)
+	}
+	n, err := io.ReadFull(response.Body, p[:length])
+	r.offset += int64(n)
+	if err != nil {
+		return n, err
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+//Seek sets offset for the next Read
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("invalid offset: %v", offset)
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+//openStream returns base stream reader reading content in option.Stream part size ranged requests,
+//the reader implements io.ReaderAt and storage.Sizer
+func (s *manager) openStream(ctx context.Context, URL string, stream *option.Stream, options []storage.Option) (io.ReadCloser, error) {
+	size := int64(stream.Size)
+	if size == 0 {
+		response, err := s.getRange(ctx, URL, 0, 1, "", options)
+		if err != nil {
+			return nil, err
+		}
+		s.closeResponse(response)
+		if !IsStatusOK(response) {
+			return nil, fmt.Errorf("resource not found, statusCode: %v, url: %v", response.StatusCode, URL)
+		}
+		if size = contentSize(response); size < 0 {
+			return nil, fmt.Errorf("unable to determine content size: %v", URL)
+		}
+	}
+	streamOption := *stream
+	streamOption.Size = int(size)
+	reader := &rangeReader{ctx: ctx, manager: s, URL: URL, options: options, size: size}
+	return base.NewStreamReader(&streamOption, reader), nil
+}
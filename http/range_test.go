@@ -0,0 +1,154 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestManager_OpenURL_Range(t *testing.T) {
+	content := strings.Repeat("0123456789", 10)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch request.URL.Path {
+		case "/norange.txt":
+			_, _ = writer.Write([]byte(content))
+		case "/interrupted.txt":
+			if request.Header.Get("Range") == "" {
+				writer.Header().Set("Accept-Ranges", "bytes")
+				writer.Header().Set("Content-Length", strconv.Itoa(len(content)))
+				writer.WriteHeader(http.StatusOK)
+				_, _ = writer.Write([]byte(content[:35]))
+				writer.(http.Flusher).Flush()
+				conn, _, _ := writer.(http.Hijacker).Hijack()
+				_ = conn.Close()
+				return
+			}
+			http.ServeContent(writer, request, request.URL.Path, time.Time{}, strings.NewReader(content))
+		default:
+			http.ServeContent(writer, request, request.URL.Path, time.Time{}, strings.NewReader(content))
+		}
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description    string
+		URL            string
+		options        []storage.Option
+		expect         string
+		expectRequests int32
+	}{
+		{
+			description:    "offset and length",
+			URL:            server.URL + "/asset.txt",
+			options:        []storage.Option{option.NewRange(5, 10)},
+			expect:         content[5:15],
+			expectRequests: 1,
+		},
+		{
+			description:    "offset till the end",
+			URL:            server.URL + "/asset.txt",
+			options:        []storage.Option{option.NewRange(95, 0)},
+			expect:         content[95:],
+			expectRequests: 1,
+		},
+		{
+			description:    "server without range support",
+			URL:            server.URL + "/norange.txt",
+			options:        []storage.Option{option.NewRange(5, 10)},
+			expect:         content[5:15],
+			expectRequests: 1,
+		},
+		{
+			description:    "resumed interrupted transfer",
+			URL:            server.URL + "/interrupted.txt",
+			expect:         content,
+			expectRequests: 2,
+		},
+		{
+			description:    "stream with part size",
+			URL:            server.URL + "/asset.txt",
+			options:        []storage.Option{option.NewStream(30, 0)},
+			expect:         content,
+			expectRequests: 5,
+		},
+	}
+
+	ctx := context.Background()
+	manager := newManager()
+	for _, useCase := range useCases {
+		atomic.StoreInt32(&requests, 0)
+		reader, err := manager.OpenURL(ctx, useCase.URL, useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+		assert.EqualValues(t, useCase.expectRequests, atomic.LoadInt32(&requests), useCase.description)
+	}
+}
+
+func TestManager_OpenURL_StreamReaderAt(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	assets := map[string]string{"a.txt": "hello", "b/b.txt": strings.Repeat("world", 100)}
+	for name, content := range assets {
+		entry, err := writer.Create(name)
+		if !assert.Nil(t, err) {
+			return
+		}
+		_, _ = entry.Write([]byte(content))
+	}
+	assert.Nil(t, writer.Close())
+	archive := buffer.Bytes()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		http.ServeContent(writer, request, request.URL.Path, time.Time{}, bytes.NewReader(archive))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	manager := newManager()
+	reader, err := manager.OpenURL(ctx, server.URL+"/archive.zip", option.NewStream(64, 0))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer reader.Close()
+	readerAt, ok := reader.(io.ReaderAt)
+	if !assert.True(t, ok) {
+		return
+	}
+	sizer, ok := reader.(storage.Sizer)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.EqualValues(t, len(archive), sizer.Size())
+	zipReader, err := zip.NewReader(readerAt, sizer.Size())
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, entry := range zipReader.File {
+		entryReader, err := entry.Open()
+		if !assert.Nil(t, err, entry.Name) {
+			continue
+		}
+		data, err := ioutil.ReadAll(entryReader)
+		_ = entryReader.Close()
+		assert.Nil(t, err, entry.Name)
+		assert.EqualValues(t, assets[entry.Name], string(data), entry.Name)
+	}
+}
@@ -17,6 +17,14 @@ func (s *manager) run(ctx context.Context, URL string, request *http.Request, op
 	s.setHeader(request, header)
 	s.setCookies(cookies, request)
 	s.authWithBasicCred(request, basicAuthProvider)
+	tokenSource := s.tokenSource(options)
+	if tokenSource != nil {
+		if err := s.authWithToken(ctx, request, tokenSource, false); err != nil {
+			return nil, err
+		}
+	} else if basicAuthProvider == nil && request.Header.Get(authorizationHeader) == "" {
+		s.authWithBasicCred(request, s.netrcAuth(request.URL.Host))
+	}
 	client, err := s.getClient(URL, options...)
 	if err != nil {
 		return nil, err
@@ -24,9 +32,27 @@ func (s *manager) run(ctx context.Context, URL string, request *http.Request, op
 	if ctx != nil {
 		request.WithContext(ctx)
 	}
-	response, err := client.Do(request)
+	var retry *http.Request
+	if tokenSource != nil {
+		retry, _ = replayRequest(request)
+	}
+	response, err := s.do(client, request, options)
+	if err == nil && retry != nil && response.StatusCode == http.StatusUnauthorized {
+		s.closeResponse(response)
+		if err = s.authWithToken(ctx, retry, tokenSource, true); err != nil {
+			return nil, err
+		}
+		response, err = s.do(client, retry, options)
+	}
 	if err == nil && resp != nil {
 		*resp = *response
 	}
 	return response, err
 }
+
+func (s *manager) do(client *http.Client, request *http.Request, options []storage.Option) (*http.Response, error) {
+	if proxy := s.proxy(options); proxy != nil {
+		return s.doWithProxy(client, request, proxy)
+	}
+	return client.Do(request)
+}
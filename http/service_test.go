@@ -0,0 +1,50 @@
+package http_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/url"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestService_CopyDirectoryIndex(t *testing.T) {
+	sourceDir := t.TempDir()
+	assets := map[string]string{
+		"asset1.txt":          "test 1",
+		"sub/asset2.txt":      "test 2",
+		"sub/sub2/asset3.txt": "test 3",
+	}
+	for name, content := range assets {
+		location := path.Join(sourceDir, name)
+		if !assert.Nil(t, os.MkdirAll(path.Dir(location), 0755)) {
+			return
+		}
+		if !assert.Nil(t, ioutil.WriteFile(location, []byte(content), 0644)) {
+			return
+		}
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/dir/", http.StripPrefix("/dir/", http.FileServer(http.Dir(sourceDir))))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	fs := afs.New()
+	destURL := "mem://localhost/mirror"
+	err := fs.Copy(ctx, server.URL+"/dir/", destURL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	for name, content := range assets {
+		data, err := fs.DownloadWithURL(ctx, url.Join(destURL, name))
+		if assert.Nil(t, err, name) {
+			assert.EqualValues(t, content, string(data), name)
+		}
+	}
+}
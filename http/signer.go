@@ -0,0 +1,99 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	neturl "net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	//ExpiresParam represents signed URL expiry query parameter
+	ExpiresParam = "X-Afs-Expires"
+	//SignatureParam represents signed URL signature query parameter
+	SignatureParam = "X-Afs-Signature"
+
+	defaultPreSignTimeToLive = 15 * time.Minute
+)
+
+//Signer represents URL signer option, it produces and verifies signed expiring URLs served by adapter/http
+type Signer struct {
+	key []byte
+}
+
+//Sign returns URL signed till expiry time
+func (s *Signer) Sign(URL string, expiry time.Time) (string, error) {
+	parsed, err := neturl.Parse(URL)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	query.Del(SignatureParam)
+	expires := strconv.FormatInt(expiry.Unix(), 10)
+	query.Set(ExpiresParam, expires)
+	query.Set(SignatureParam, s.signature(parsed.Path, expires))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+//Verify checks supplied URL signature and expiry
+func (s *Signer) Verify(URL *neturl.URL, now time.Time) error {
+	query := URL.Query()
+	expires := query.Get(ExpiresParam)
+	signature := query.Get(SignatureParam)
+	if expires == "" || signature == "" {
+		return fmt.Errorf("URL is not signed: %v", URL.Path)
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(URL.Path, expires))) {
+		return fmt.Errorf("invalid URL signature: %v", URL.Path)
+	}
+	expiry, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid URL expiry: %v", expires)
+	}
+	if now.Unix() > expiry {
+		return fmt.Errorf("signed URL expired: %v", URL.Path)
+	}
+	return nil
+}
+
+func (s *Signer) signature(URLPath, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(URLPath + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+//NewSigner creates a URL signer for supplied secret key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+//preSign assigns signed URL to option.PreSign if supplied
+func (s *manager) preSign(URL string, options []storage.Option) error {
+	preSign := &option.PreSign{}
+	if _, ok := option.Assign(options, &preSign); !ok || preSign == nil {
+		return nil
+	}
+	if len(s.options) > 0 {
+		options = append(s.options, options...)
+	}
+	signer := &Signer{}
+	if _, ok := option.Assign(options, &signer); !ok || signer == nil {
+		return fmt.Errorf("failed to presign %v: signer option was missing", URL)
+	}
+	timeToLive := preSign.TimeToLive
+	if timeToLive == 0 {
+		timeToLive = defaultPreSignTimeToLive
+	}
+	signed, err := signer.Sign(URL, time.Now().Add(timeToLive))
+	if err != nil {
+		return err
+	}
+	preSign.URL = signed
+	return nil
+}
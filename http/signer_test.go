@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSigner_Verify(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	now := time.Now()
+	var useCases = []struct {
+		description string
+		URL         string
+		expiry      time.Time
+		tamper      func(URL string) string
+		hasError    bool
+	}{
+		{
+			description: "valid signature",
+			URL:         "http://localhost:8080/data/asset.txt",
+			expiry:      now.Add(time.Minute),
+		},
+		{
+			description: "valid signature with query",
+			URL:         "http://localhost:8080/data/asset.txt?v=1",
+			expiry:      now.Add(time.Minute),
+		},
+		{
+			description: "expired signature",
+			URL:         "http://localhost:8080/data/asset.txt",
+			expiry:      now.Add(-time.Minute),
+			hasError:    true,
+		},
+		{
+			description: "tampered path",
+			URL:         "http://localhost:8080/data/asset.txt",
+			expiry:      now.Add(time.Minute),
+			tamper: func(URL string) string {
+				return strings.Replace(URL, "asset.txt", "secret.txt", 1)
+			},
+			hasError: true,
+		},
+		{
+			description: "other key",
+			URL:         "http://localhost:8080/data/asset.txt",
+			expiry:      now.Add(time.Minute),
+			tamper: func(URL string) string {
+				signed, _ := NewSigner([]byte("other")).Sign(URL, now.Add(time.Minute))
+				return signed
+			},
+			hasError: true,
+		},
+		{
+			description: "unsigned",
+			URL:         "http://localhost:8080/data/asset.txt",
+			tamper: func(URL string) string {
+				return "http://localhost:8080/data/asset.txt"
+			},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		signed, err := signer.Sign(useCase.URL, useCase.expiry)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.tamper != nil {
+			signed = useCase.tamper(signed)
+		}
+		parsed, err := neturl.Parse(signed)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		err = signer.Verify(parsed, now)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		assert.Nil(t, err, useCase.description)
+	}
+}
+
+func TestManager_PreSign(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = writer.Write([]byte("test"))
+	}))
+	defer server.Close()
+	signer := NewSigner([]byte("secret"))
+
+	var useCases = []struct {
+		description    string
+		managerOptions []storage.Option
+		options        []storage.Option
+		timeToLive     time.Duration
+		hasError       bool
+	}{
+		{
+			description: "presign with call signer",
+			options:     []storage.Option{signer},
+			timeToLive:  time.Minute,
+		},
+		{
+			description:    "presign with manager signer and default time to live",
+			managerOptions: []storage.Option{signer},
+		},
+		{
+			description: "presign without signer",
+			hasError:    true,
+		},
+	}
+
+	ctx := context.Background()
+	URL := server.URL + "/data/asset.txt"
+	for _, useCase := range useCases {
+		manager := newManager(useCase.managerOptions...)
+		preSign := option.NewPreSign(useCase.timeToLive)
+		atomic.StoreInt32(&requests, 0)
+		reader, err := manager.OpenURL(ctx, URL, append(useCase.options, preSign)...)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		_ = reader.Close()
+		assert.EqualValues(t, 1, atomic.LoadInt32(&requests), useCase.description)
+		signed, err := neturl.Parse(preSign.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.True(t, strings.HasPrefix(preSign.URL, URL+"?"), useCase.description)
+		assert.Nil(t, signer.Verify(signed, time.Now()), useCase.description)
+		assert.NotNil(t, signer.Verify(signed, time.Now().Add(time.Hour)), useCase.description)
+	}
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+	tokenExpirySkew     = 10 * time.Second
+)
+
+//TokenSource represents bearer token source option
+type TokenSource interface {
+	//Token returns access token, refresh forces a new token (i.e. after 401 response)
+	Token(ctx context.Context, refresh bool) (string, error)
+}
+
+type bearer struct {
+	token string
+}
+
+//Token returns static token
+func (b *bearer) Token(ctx context.Context, refresh bool) (string, error) {
+	return b.token, nil
+}
+
+//NewBearer creates static bearer token source
+func NewBearer(token string) TokenSource {
+	return &bearer{token: token}
+}
+
+//ClientCredentials represents OAuth2 client credentials grant token source, token is refreshed on expiry or on demand
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	//ClientProvider optional token endpoint client provider
+	ClientProvider ClientProvider
+	mux            sync.Mutex
+	token          string
+	expiry         time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+//Token returns cached access token unless it has expired or refresh was requested
+func (c *ClientCredentials) Token(ctx context.Context, refresh bool) (string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if !refresh && c.token != "" && (c.expiry.IsZero() || time.Now().Add(tokenExpirySkew).Before(c.expiry)) {
+		return c.token, nil
+	}
+	form := neturl.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+	request, err := http.NewRequest(http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	if ctx != nil {
+		request = request.WithContext(ctx)
+	}
+	request.Header.Set(contentTypeHeader, "application/x-www-form-urlencoded")
+	request.SetBasicAuth(neturl.QueryEscape(c.ClientID), neturl.QueryEscape(c.ClientSecret))
+	client := http.DefaultClient
+	if c.ClientProvider != nil {
+		if client, err = c.ClientProvider(c.TokenURL); err != nil {
+			return "", err
+		}
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %v, %w", c.TokenURL, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if !IsStatusOK(response) {
+		return "", fmt.Errorf("failed to get token, statusCode: %v, url: %v", response.StatusCode, c.TokenURL)
+	}
+	result := &tokenResponse{}
+	if err = json.NewDecoder(response.Body).Decode(result); err != nil {
+		return "", fmt.Errorf("invalid token response: %v, %w", c.TokenURL, err)
+	}
+	if result.AccessToken == "" {
+		return "", fmt.Errorf("access token was empty: %v", c.TokenURL)
+	}
+	c.token = result.AccessToken
+	c.expiry = time.Time{}
+	if result.ExpiresIn > 0 {
+		c.expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return c.token, nil
+}
+
+//NewClientCredentials creates OAuth2 client credentials token source
+func NewClientCredentials(tokenURL, clientID, clientSecret string, scopes ...string) *ClientCredentials {
+	return &ClientCredentials{TokenURL: tokenURL, ClientID: clientID, ClientSecret: clientSecret, Scopes: scopes}
+}
+
+//sameTokenSource returns true if both token sources represent the same credentials
+func sameTokenSource(source, candidate TokenSource) bool {
+	if source == candidate {
+		return true
+	}
+	switch actual := source.(type) {
+	case *bearer:
+		expected, ok := candidate.(*bearer)
+		return ok && actual.token == expected.token
+	case *ClientCredentials:
+		expected, ok := candidate.(*ClientCredentials)
+		return ok && actual.TokenURL == expected.TokenURL && actual.ClientID == expected.ClientID &&
+			actual.ClientSecret == expected.ClientSecret && strings.Join(actual.Scopes, " ") == strings.Join(expected.Scopes, " ")
+	}
+	return false
+}
+
+//tokenSource returns call or manager level token source or nil
+func (s *manager) tokenSource(options []storage.Option) TokenSource {
+	var tokenSource TokenSource
+	if option.Assign(options, &tokenSource); tokenSource == nil {
+		option.Assign(s.options, &tokenSource)
+	}
+	return tokenSource
+}
+
+//authWithToken sets bearer authorization header
+func (s *manager) authWithToken(ctx context.Context, request *http.Request, tokenSource TokenSource, refresh bool) error {
+	token, err := tokenSource.Token(ctx, refresh)
+	if err != nil {
+		return err
+	}
+	if request.Header == nil {
+		request.Header = http.Header{}
+	}
+	request.Header.Set(authorizationHeader, bearerPrefix+token)
+	return nil
+}
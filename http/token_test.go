@@ -0,0 +1,147 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+//oauthServer issues client credentials tokens and serves resources for a valid bearer token
+type oauthServer struct {
+	mux       sync.Mutex
+	issued    int
+	valid     string
+	expiresIn int
+}
+
+func (s *oauthServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if request.URL.Path == "/token" {
+		clientID, secret, _ := request.BasicAuth()
+		if clientID != "client" || secret != "secret" || request.FormValue("grant_type") != "client_credentials" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.issued++
+		s.valid = fmt.Sprintf("token-%d", s.issued)
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{"access_token": s.valid, "token_type": "Bearer", "expires_in": s.expiresIn})
+		return
+	}
+	if request.Header.Get(authorizationHeader) != bearerPrefix+s.valid {
+		writer.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if request.Method == http.MethodPut {
+		data, _ := ioutil.ReadAll(request.Body)
+		_, _ = writer.Write(data)
+		return
+	}
+	_, _ = writer.Write([]byte("test"))
+}
+
+func (s *oauthServer) revoke() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.valid = "revoked"
+}
+
+func TestManager_TokenSource(t *testing.T) {
+	handler := &oauthServer{expiresIn: 3600}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	credentials := NewClientCredentials(server.URL+"/token", "client", "secret", "read")
+
+	var useCases = []struct {
+		description  string
+		options      []storage.Option
+		revoke       bool
+		expiresIn    int
+		body         string
+		expectStatus int
+		expectIssued int
+	}{
+		{
+			description:  "static bearer",
+			options:      []storage.Option{NewBearer("token-0")},
+			expectStatus: http.StatusOK,
+		},
+		{
+			description:  "invalid static bearer",
+			options:      []storage.Option{NewBearer("invalid")},
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			description:  "client credentials token",
+			options:      []storage.Option{credentials},
+			expectStatus: http.StatusOK,
+			expectIssued: 1,
+		},
+		{
+			description:  "client credentials cached token",
+			options:      []storage.Option{credentials},
+			expectStatus: http.StatusOK,
+		},
+		{
+			description:  "client credentials refresh on 401",
+			options:      []storage.Option{credentials},
+			revoke:       true,
+			expectStatus: http.StatusOK,
+			expectIssued: 1,
+		},
+		{
+			description:  "client credentials refresh on 401 with body",
+			options:      []storage.Option{credentials, option.HTTPMethod(http.MethodPut), strings.NewReader("test")},
+			revoke:       true,
+			expectStatus: http.StatusOK,
+			expectIssued: 1,
+		},
+		{
+			description:  "client credentials refresh on expiry",
+			options:      []storage.Option{NewClientCredentials(server.URL+"/token", "client", "secret")},
+			expiresIn:    1,
+			expectStatus: http.StatusOK,
+			expectIssued: 2,
+		},
+	}
+
+	ctx := context.Background()
+	handler.valid = "token-0"
+	for _, useCase := range useCases {
+		if useCase.revoke {
+			handler.revoke()
+		}
+		if useCase.expiresIn > 0 {
+			handler.expiresIn = useCase.expiresIn
+		}
+		issued := handler.issued
+		manager := newManager()
+		status := option.NewStatus()
+		iterations := 1
+		if useCase.expiresIn > 0 {
+			iterations = 2
+		}
+		for i := 0; i < iterations; i++ {
+			reader, err := manager.OpenURL(ctx, server.URL+"/data/asset.txt", append(useCase.options, status)...)
+			if !assert.Nil(t, err, useCase.description) {
+				break
+			}
+			data, _ := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			if useCase.expectStatus == http.StatusOK {
+				assert.EqualValues(t, "test", string(data), useCase.description)
+			}
+		}
+		assert.EqualValues(t, useCase.expectStatus, status.Code, useCase.description)
+		assert.EqualValues(t, useCase.expectIssued, handler.issued-issued, useCase.description)
+	}
+}
@@ -0,0 +1,291 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	tusVersion           = "1.0.0"
+	tusResumableHeader   = "Tus-Resumable"
+	uploadOffsetHeader   = "Upload-Offset"
+	uploadLengthHeader   = "Upload-Length"
+	uploadDeferLength    = "Upload-Defer-Length"
+	uploadMetadataHeader = "Upload-Metadata"
+	tusContentType       = "application/offset+octet-stream"
+	defaultTusChunkSize  = 8 * 1024 * 1024
+	defaultTusRetries    = 3
+	contentTypeHeader    = "Content-Type"
+	locationHeader       = "Location"
+)
+
+//Tus represents tus.io resumable upload option
+type Tus struct {
+	//Endpoint represents upload creation endpoint, upload URL is used by default
+	Endpoint string
+	//ChunkSize represents PATCH request content size
+	ChunkSize int
+	//Retries represents max resume attempts per chunk
+	Retries int
+	//UploadURL represents created upload URL, supply it to resume previously interrupted upload
+	UploadURL string
+}
+
+func (t *Tus) init(URL string) {
+	if t.Endpoint == "" {
+		t.Endpoint = URL
+	}
+	if t.ChunkSize == 0 {
+		t.ChunkSize = defaultTusChunkSize
+	}
+	if t.Retries == 0 {
+		t.Retries = defaultTusRetries
+	}
+}
+
+//NewTus creates tus upload option
+func NewTus(endpoint string, chunkSize int) *Tus {
+	return &Tus{Endpoint: endpoint, ChunkSize: chunkSize}
+}
+
+//tusWriter uploads written content with tus PATCH requests
+type tusWriter struct {
+	ctx     context.Context
+	manager *manager
+	tus     *Tus
+	options []storage.Option
+	length  int64
+	offset  int64
+	skip    int64
+	buffer  []byte
+	closed  bool
+}
+
+//Write buffers data and uploads full chunks, data already stored by the server (resumed upload) is skipped
+func (w *tusWriter) Write(data []byte) (int, error) {
+	written := len(data)
+	if w.skip > 0 {
+		skip := w.skip
+		if skip > int64(len(data)) {
+			skip = int64(len(data))
+		}
+		data = data[skip:]
+		w.skip -= skip
+	}
+	w.buffer = append(w.buffer, data...)
+	for len(w.buffer) >= w.tus.ChunkSize {
+		if err := w.patch(w.buffer[:w.tus.ChunkSize], false); err != nil {
+			return 0, err
+		}
+		w.buffer = w.buffer[w.tus.ChunkSize:]
+	}
+	return written, nil
+}
+
+//Close uploads remaining content and declares upload length if it was deferred, it returns an error if fewer bytes than declared were written
+func (w *tusWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if len(w.buffer) > 0 || w.length < 0 {
+		if err := w.patch(w.buffer, true); err != nil {
+			return err
+		}
+	}
+	if w.length >= 0 && w.offset != w.length {
+		return fmt.Errorf("incomplete upload: %v of %v bytes, url: %v", w.offset, w.length, w.tus.UploadURL)
+	}
+	return nil
+}
+
+//patch sends a chunk, on failure it reads server offset with HEAD and resends remaining chunk part
+func (w *tusWriter) patch(chunk []byte, final bool) error {
+	chunkOffset := w.offset
+	var err error
+	for attempt := 0; attempt <= w.tus.Retries; attempt++ {
+		if attempt > 0 {
+			var serverOffset int64
+			if serverOffset, _, err = w.manager.tusOffset(w.ctx, w.tus.UploadURL, w.options); err != nil {
+				continue
+			}
+			if serverOffset < chunkOffset || serverOffset > chunkOffset+int64(len(chunk)) {
+				return fmt.Errorf("unexpected upload offset: %v, expected range: %v-%v, url: %v", serverOffset, chunkOffset, chunkOffset+int64(len(chunk)), w.tus.UploadURL)
+			}
+			w.offset = serverOffset
+		}
+		pending := chunk[w.offset-chunkOffset:]
+		if len(pending) == 0 && (!final || w.length >= 0) {
+			return nil
+		}
+		if err = w.send(pending, final); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (w *tusWriter) send(data []byte, final bool) error {
+	request, err := http.NewRequest(http.MethodPatch, w.tus.UploadURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	request.Header = http.Header{}
+	request.Header.Set(tusResumableHeader, tusVersion)
+	request.Header.Set(contentTypeHeader, tusContentType)
+	request.Header.Set(uploadOffsetHeader, strconv.FormatInt(w.offset, 10))
+	if final && w.length < 0 {
+		request.Header.Set(uploadLengthHeader, strconv.FormatInt(w.offset+int64(len(data)), 10))
+	}
+	response, err := w.manager.run(w.ctx, w.tus.UploadURL, request, w.options...)
+	if err != nil {
+		return err
+	}
+	defer w.manager.closeResponse(response)
+	if !IsStatusOK(response) {
+		return fmt.Errorf("failed to patch upload, statusCode: %v, url: %v", response.StatusCode, w.tus.UploadURL)
+	}
+	offset, err := strconv.ParseInt(response.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %v header: %v, url: %v", uploadOffsetHeader, response.Header.Get(uploadOffsetHeader), w.tus.UploadURL)
+	}
+	w.offset = offset
+	return nil
+}
+
+//tusOffset returns upload offset and length (-1 if deferred) with HEAD request
+func (s *manager) tusOffset(ctx context.Context, uploadURL string, options []storage.Option) (int64, int64, error) {
+	request, err := http.NewRequest(http.MethodHead, uploadURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	request.Header = http.Header{}
+	request.Header.Set(tusResumableHeader, tusVersion)
+	response, err := s.run(ctx, uploadURL, request, options...)
+	if err != nil {
+		return 0, 0, err
+	}
+	s.closeResponse(response)
+	if !IsStatusOK(response) {
+		return 0, 0, fmt.Errorf("failed to get upload offset, statusCode: %v, url: %v", response.StatusCode, uploadURL)
+	}
+	offset, err := strconv.ParseInt(response.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %v header: %v, url: %v", uploadOffsetHeader, response.Header.Get(uploadOffsetHeader), uploadURL)
+	}
+	length := int64(-1)
+	if value := response.Header.Get(uploadLengthHeader); value != "" {
+		length, _ = strconv.ParseInt(value, 10, 64)
+	}
+	return offset, length, nil
+}
+
+//tusCreate creates an upload, length -1 defers upload length
+func (s *manager) tusCreate(ctx context.Context, URL string, tus *Tus, length int64, options []storage.Option) error {
+	request, err := http.NewRequest(http.MethodPost, tus.Endpoint, nil)
+	if err != nil {
+		return err
+	}
+	request.Header = http.Header{}
+	request.Header.Set(tusResumableHeader, tusVersion)
+	if length >= 0 {
+		request.Header.Set(uploadLengthHeader, strconv.FormatInt(length, 10))
+	} else {
+		request.Header.Set(uploadDeferLength, "1")
+	}
+	request.Header.Set(uploadMetadataHeader, tusMetadata(URL))
+	response, err := s.run(ctx, tus.Endpoint, request, options...)
+	if err != nil {
+		return err
+	}
+	s.closeResponse(response)
+	if response.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create upload, statusCode: %v, url: %v", response.StatusCode, tus.Endpoint)
+	}
+	location, err := neturl.Parse(response.Header.Get(locationHeader))
+	if err != nil || location.String() == "" {
+		return fmt.Errorf("invalid upload location: %v, url: %v", response.Header.Get(locationHeader), tus.Endpoint)
+	}
+	endpoint, err := neturl.Parse(tus.Endpoint)
+	if err != nil {
+		return err
+	}
+	tus.UploadURL = endpoint.ResolveReference(location).String()
+	return nil
+}
+
+//tusMetadata returns upload metadata with destination filename and path
+func tusMetadata(URL string) string {
+	URLPath := URL
+	if parsed, err := neturl.Parse(URL); err == nil {
+		URLPath = parsed.Path
+	}
+	encode := func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	return strings.Join([]string{"filename " + encode(path.Base(URLPath)), "path " + encode(URLPath)}, ",")
+}
+
+//newTusWriter creates or resumes (when Tus.UploadURL is set) tus upload
+func (s *manager) newTusWriter(ctx context.Context, URL string, tus *Tus, length int64, options []storage.Option) (*tusWriter, error) {
+	tus.init(URL)
+	writer := &tusWriter{ctx: ctx, manager: s, tus: tus, options: options, length: length}
+	if tus.UploadURL == "" {
+		return writer, s.tusCreate(ctx, URL, tus, length, options)
+	}
+	offset, serverLength, err := s.tusOffset(ctx, tus.UploadURL, options)
+	if err != nil {
+		return nil, err
+	}
+	if length < 0 {
+		writer.length = serverLength
+	}
+	writer.offset = offset
+	writer.skip = offset
+	return writer, nil
+}
+
+//uploadTus uploads reader content with tus protocol
+func (s *manager) uploadTus(ctx context.Context, URL string, tus *Tus, reader io.Reader, options []storage.Option) error {
+	length := int64(-1)
+	if sizer, ok := reader.(storage.Sizer); ok {
+		length = sizer.Size()
+	}
+	writer, err := s.newTusWriter(ctx, URL, tus, length, options)
+	if err != nil {
+		return err
+	}
+	if seeker, ok := reader.(io.Seeker); ok && writer.skip > 0 {
+		if _, err = seeker.Seek(writer.skip, io.SeekStart); err != nil {
+			return err
+		}
+		writer.skip = 0
+	}
+	if _, err = io.CopyBuffer(writer, reader, make([]byte, tus.ChunkSize)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+//tusOption returns tus option or nil, manager level option is copied as upload URL is assigned per upload
+func (s *manager) tusOption(options []storage.Option) *Tus {
+	tus := &Tus{}
+	if _, ok := option.Assign(options, &tus); ok && tus != nil {
+		return tus
+	}
+	if _, ok := option.Assign(s.options, &tus); ok && tus != nil {
+		upload := *tus
+		return &upload
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type tusUpload struct {
+	data   []byte
+	length int64
+}
+
+//tusServer represents minimal tus.io server stand-in, failPatch interrupts selected PATCH request after reading half of the chunk
+type tusServer struct {
+	mux       sync.Mutex
+	uploads   map[string]*tusUpload
+	patches   int
+	failPatch int
+}
+
+func (s *tusServer) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if request.Header.Get(tusResumableHeader) != tusVersion {
+		writer.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	writer.Header().Set(tusResumableHeader, tusVersion)
+	switch request.Method {
+	case http.MethodPost:
+		upload := &tusUpload{length: -1}
+		if value := request.Header.Get(uploadLengthHeader); value != "" {
+			upload.length, _ = strconv.ParseInt(value, 10, 64)
+		}
+		id := fmt.Sprintf("/files/%d", len(s.uploads)+1)
+		s.uploads[id] = upload
+		writer.Header().Set(locationHeader, id)
+		writer.WriteHeader(http.StatusCreated)
+		return
+	}
+	upload, ok := s.uploads[request.URL.Path]
+	if !ok {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch request.Method {
+	case http.MethodHead:
+		writer.Header().Set(uploadOffsetHeader, strconv.Itoa(len(upload.data)))
+		if upload.length >= 0 {
+			writer.Header().Set(uploadLengthHeader, strconv.FormatInt(upload.length, 10))
+		}
+		writer.WriteHeader(http.StatusOK)
+	case http.MethodPatch:
+		offset, _ := strconv.Atoi(request.Header.Get(uploadOffsetHeader))
+		if offset != len(upload.data) {
+			writer.WriteHeader(http.StatusConflict)
+			return
+		}
+		data, _ := ioutil.ReadAll(request.Body)
+		s.patches++
+		if s.patches == s.failPatch {
+			upload.data = append(upload.data, data[:len(data)/2]...)
+			conn, _, _ := writer.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		upload.data = append(upload.data, data...)
+		if value := request.Header.Get(uploadLengthHeader); value != "" {
+			upload.length, _ = strconv.ParseInt(value, 10, 64)
+		}
+		writer.Header().Set(uploadOffsetHeader, strconv.Itoa(len(upload.data)))
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestManager_Upload_Tus(t *testing.T) {
+	content := strings.Repeat("0123456789", 9) + "abcde"
+	var useCases = []struct {
+		description   string
+		reader        func() io.Reader
+		resumeOffset  int
+		failPatch     int
+		useWriter     bool
+		expectPatches int
+		expectLength  int64
+		expectError   bool
+	}{
+		{
+			description:   "known length upload",
+			reader:        func() io.Reader { return strings.NewReader(content) },
+			expectPatches: 4,
+			expectLength:  int64(len(content)),
+		},
+		{
+			description:   "deferred length upload",
+			reader:        func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			expectPatches: 4,
+			expectLength:  int64(len(content)),
+		},
+		{
+			description:   "interrupted chunk resumed",
+			reader:        func() io.Reader { return strings.NewReader(content) },
+			failPatch:     2,
+			expectPatches: 5,
+			expectLength:  int64(len(content)),
+		},
+		{
+			description:   "resumed upload",
+			reader:        func() io.Reader { return io.MultiReader(strings.NewReader(content)) },
+			resumeOffset:  45,
+			expectPatches: 2,
+			expectLength:  int64(len(content)),
+		},
+		{
+			description:   "writer upload",
+			reader:        func() io.Reader { return strings.NewReader(content) },
+			useWriter:     true,
+			expectPatches: 4,
+			expectLength:  int64(len(content)),
+		},
+		{
+			description: "incomplete known length upload",
+			reader: func() io.Reader {
+				return &sizedReader{Reader: strings.NewReader(content[:50]), size: int64(len(content))}
+			},
+			expectError: true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		handler := &tusServer{uploads: map[string]*tusUpload{}, failPatch: useCase.failPatch}
+		server := httptest.NewServer(handler)
+		tus := NewTus(server.URL+"/files/", 30)
+		if useCase.resumeOffset > 0 {
+			handler.uploads["/files/0"] = &tusUpload{data: []byte(content[:useCase.resumeOffset]), length: -1}
+			tus.UploadURL = server.URL + "/files/0"
+		}
+		manager := newManager()
+		URL := server.URL + "/data/asset.txt"
+		var err error
+		if useCase.useWriter {
+			var writer io.WriteCloser
+			if writer, err = manager.NewWriter(ctx, URL, 0644, tus); assert.Nil(t, err, useCase.description) {
+				_, err = io.Copy(writer, useCase.reader())
+				assert.Nil(t, err, useCase.description)
+				err = writer.Close()
+			}
+		} else {
+			err = manager.Upload(ctx, URL, 0644, useCase.reader(), tus)
+		}
+		server.Close()
+		if useCase.expectError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		upload := handler.uploads[tus.UploadURL[len(server.URL):]]
+		if !assert.NotNil(t, upload, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, content, string(upload.data), useCase.description)
+		assert.EqualValues(t, useCase.expectLength, upload.length, useCase.description)
+		assert.EqualValues(t, useCase.expectPatches, handler.patches, useCase.description)
+	}
+}
+
+//sizedReader represents a reader declaring its size
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+func (r *sizedReader) Size() int64 {
+	return r.size
+}
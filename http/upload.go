@@ -9,8 +9,11 @@ import (
 	"os"
 )
 
-//Upload sends put request to supplied URL with provided reader
+//Upload sends put request to supplied URL with provided reader, Tus option enables resumable chunked upload
 func (s *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	if tus := s.tusOption(options); tus != nil {
+		return s.uploadTus(ctx, URL, tus, reader, options)
+	}
 	request, err := http.NewRequest(http.MethodPut, URL, reader)
 	if err != nil {
 		return err
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+)
+
+//pipeWriter uploads written content with a single PUT request
+type pipeWriter struct {
+	*io.PipeWriter
+	done chan error
+}
+
+//Close closes the pipe and waits for upload completion
+func (w *pipeWriter) Close() error {
+	if err := w.PipeWriter.Close(); err != nil {
+		return err
+	}
+	return <-w.done
+}
+
+//NewWriter returns an upload writer, Tus option enables resumable chunked upload
+func (s *manager) NewWriter(ctx context.Context, URL string, mode os.FileMode, options ...storage.Option) (io.WriteCloser, error) {
+	if tus := s.tusOption(options); tus != nil {
+		return s.newTusWriter(ctx, URL, tus, -1, options)
+	}
+	reader, writer := io.Pipe()
+	result := &pipeWriter{PipeWriter: writer, done: make(chan error, 1)}
+	go func() {
+		err := s.Upload(ctx, URL, mode, reader, options...)
+		_ = reader.CloseWithError(err)
+		result.done <- err
+	}()
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestManager_NewWriter(t *testing.T) {
+	uploaded := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPut {
+			writer.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		data, _ := ioutil.ReadAll(request.Body)
+		uploaded[request.URL.Path] = string(data)
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description string
+		URLPath     string
+		parts       []string
+		expect      string
+	}{
+		{
+			description: "multi part write",
+			URLPath:     "/data/asset1.txt",
+			parts:       []string{"test", " is ", "test"},
+			expect:      "test is test",
+		},
+		{
+			description: "empty write",
+			URLPath:     "/data/asset2.txt",
+		},
+	}
+
+	ctx := context.Background()
+	manager := newManager()
+	for _, useCase := range useCases {
+		writer, err := manager.NewWriter(ctx, server.URL+useCase.URLPath, 0644)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		for _, part := range useCase.parts {
+			_, err = writer.Write([]byte(part))
+			assert.Nil(t, err, useCase.description)
+		}
+		assert.Nil(t, writer.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, uploaded[useCase.URLPath], useCase.description)
+	}
+}
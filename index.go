@@ -0,0 +1,97 @@
+package afs
+
+import (
+	"context"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"path"
+	"sort"
+)
+
+//index returns location entries keyed by relative path, a file location is keyed with empty path
+func (s *service) index(ctx context.Context, URL string, options []storage.Option) (map[string]storage.Object, error) {
+	var result = make(map[string]storage.Object)
+	object, err := s.Object(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	if !object.IsDir() {
+		result[""] = object
+		return result, nil
+	}
+	return result, indexFolder(ctx, s, URL, "", options, result)
+}
+
+//Index returns folder entries keyed by path relative to URL, sub folders are traversed regardless of match options
+func Index(ctx context.Context, lister storage.Lister, URL string, options ...storage.Option) (map[string]storage.Object, error) {
+	var result = make(map[string]storage.Object)
+	return result, indexFolder(ctx, lister, URL, "", options, result)
+}
+
+func indexFolder(ctx context.Context, lister storage.Lister, URL, parent string, options []storage.Option, result map[string]storage.Object) error {
+	objects, err := lister.List(ctx, URL, options...)
+	if err != nil {
+		return err
+	}
+	var dirs = make([]storage.Object, 0)
+	for _, object := range objects {
+		if object.IsDir() && url.Equals(URL, object.URL()) {
+			continue
+		}
+		result[path.Join(parent, object.Name())] = object
+		if object.IsDir() {
+			dirs = append(dirs, object)
+		}
+	}
+	var match option.Match
+	var aMatcher option.Matcher
+	if remaining, ok := option.Assign(options, &match, &aMatcher); ok {
+		isDir := true
+		dirMatcher := &matcher.Basic{Directory: &isDir}
+		if dirs, err = lister.List(ctx, URL, append(remaining, dirMatcher.Match)...); err != nil {
+			return err
+		}
+	}
+	for _, dir := range dirs {
+		if url.Equals(URL, dir.URL()) {
+			continue
+		}
+		if err = indexFolder(ctx, lister, dir.URL(), path.Join(parent, dir.Name()), options, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//matchOptions returns supplied match options
+func matchOptions(options []storage.Option) []storage.Option {
+	var match option.Match
+	var aMatcher option.Matcher
+	option.Assign(options, &match, &aMatcher)
+	var result []storage.Option
+	if match != nil {
+		result = append(result, match)
+	}
+	if aMatcher != nil {
+		result = append(result, aMatcher)
+	}
+	return result
+}
+
+func joinURL(baseURL, relative string) string {
+	if relative == "" {
+		return baseURL
+	}
+	return url.Join(baseURL, relative)
+}
+
+func sortedKeys(objects map[string]storage.Object) []string {
+	var result = make([]string, 0, len(objects))
+	for key := range objects {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -1,10 +1,14 @@
 package afs
 
 import (
+	"github.com/viant/afs/compress"
+	"github.com/viant/afs/dav"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/http"
+	"github.com/viant/afs/iofs"
 	"github.com/viant/afs/mem"
 	"github.com/viant/afs/scp"
+	"github.com/viant/afs/sftp"
 	"github.com/viant/afs/ssh"
 	"github.com/viant/afs/tar"
 	"github.com/viant/afs/zip"
@@ -16,8 +20,15 @@ func init() {
 	registry.Register(mem.Scheme, mem.Provider)
 	registry.Register(http.Scheme, http.Provider)
 	registry.Register(http.SecureScheme, http.Provider)
+	registry.Register(dav.Scheme, dav.Provider)
+	registry.Register(dav.SecureScheme, dav.Provider)
 	registry.Register(scp.Scheme, scp.Provider)
 	registry.Register(ssh.Scheme, scp.Provider)
+	registry.Register(sftp.Scheme, sftp.Provider)
+	registry.Register(iofs.Scheme, iofs.Provider)
 	registry.Register(zip.Scheme, zip.Provider)
 	registry.Register(tar.Scheme, tar.Provider)
+	registry.Register(compress.GzScheme, compress.StreamProvider(compress.GzScheme))
+	registry.Register(compress.Bz2Scheme, compress.StreamProvider(compress.Bz2Scheme))
+	registry.Register(compress.XzScheme, compress.StreamProvider(compress.XzScheme))
 }
@@ -0,0 +1,10 @@
+// Package iofs defines read-only storage manager for any io/fs filesystem (os.DirFS, fstest.MapFS, zip.Reader, embed.FS)
+//
+// Filesystem is supplied as manager or call option, URL host identifies a mount, i.e. iofs://templates/folder/a.tmpl
+//
+// Usage:
+//
+//	fs := afs.New()
+//	objects, err := fs.List(ctx, "iofs://data/folder", os.DirFS("/tmp/data"))
+//	err = fs.Copy(ctx, "iofs://data/folder", "mem://localhost/folder")
+package iofs
@@ -0,0 +1,80 @@
+package iofs
+
+import (
+	"context"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io/fs"
+	"net/http"
+)
+
+//List lists URL resources, directory is listed first followed by its entries
+func (s *manager) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	fsys, err := s.filesystem(options)
+	if err != nil {
+		return nil, err
+	}
+	name := namePath(URL)
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []storage.Object{object.New(URL, info, nil)}, nil
+	}
+	entries, err := fs.ReadDir(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	match, page := option.GetListOptions(options)
+	parent := url.Path(URL)
+	var result = make([]storage.Object, 0, len(entries)+1)
+	result = append(result, object.New(URL, info, nil))
+	for _, entry := range entries {
+		entryInfo, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if !match(parent, entryInfo) {
+			continue
+		}
+		page.Increment()
+		if page.ShallSkip() {
+			continue
+		}
+		result = append(result, object.New(url.Join(URL, entry.Name()), entryInfo, nil))
+		if page.HasReachedLimit() {
+			break
+		}
+	}
+	return result, nil
+}
+
+//Object returns an object for supplied URL
+func (s *manager) Object(ctx context.Context, URL string, options ...storage.Option) (storage.Object, error) {
+	fsys, err := s.filesystem(options)
+	if err != nil {
+		return nil, err
+	}
+	name := namePath(URL)
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return object.New(URL, info, nil), nil
+}
+
+//Exists returns true if resource exists
+func (s *manager) Exists(ctx context.Context, URL string, options ...storage.Option) (bool, error) {
+	_, err := s.Object(ctx, URL, options...)
+	if err == nil {
+		return true, nil
+	}
+	if s.ErrorCode(err) == http.StatusNotFound {
+		return false, nil
+	}
+	return false, err
+}
+
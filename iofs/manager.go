@@ -0,0 +1,87 @@
+package iofs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+)
+
+type manager struct {
+	fs fs.FS
+}
+
+//Upload unsupported
+func (s *manager) Upload(ctx context.Context, URL string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	return fmt.Errorf("unsupported Upload operation for %v", URL)
+}
+
+//Delete unsupported
+func (s *manager) Delete(ctx context.Context, URL string, options ...storage.Option) error {
+	return fmt.Errorf("unsupported Delete operation for %v", URL)
+}
+
+//Create unsupported
+func (s *manager) Create(ctx context.Context, URL string, mode os.FileMode, isDir bool, options ...storage.Option) error {
+	return fmt.Errorf("unsupported Create operation for %v", URL)
+}
+
+//ErrorCode returns an error code
+func (s *manager) ErrorCode(err error) int {
+	if errors.Is(err, fs.ErrNotExist) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return http.StatusForbidden
+	}
+	return 0
+}
+
+//Close closes manager
+func (s *manager) Close() error {
+	return nil
+}
+
+//Scheme returns scheme
+func (s *manager) Scheme() string {
+	return Scheme
+}
+
+//filesystem returns call option or manager filesystem
+func (s *manager) filesystem(options []storage.Option) (fs.FS, error) {
+	var fsys fs.FS
+	if option.Assign(options, &fsys); fsys != nil {
+		return fsys, nil
+	}
+	if s.fs == nil {
+		return nil, fmt.Errorf("%v filesystem option was missing", Scheme)
+	}
+	return s.fs, nil
+}
+
+//namePath returns io/fs name for supplied URL
+func namePath(URL string) string {
+	name := strings.Trim(url.Path(URL), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+func newManager(options ...storage.Option) *manager {
+	var fsys fs.FS
+	option.Assign(options, &fsys)
+	return &manager{fs: fsys}
+}
+
+//New creates io/fs filesystem manager, filesystem can be supplied as manager or call option
+func New(options ...storage.Option) storage.Manager {
+	return newManager(options...)
+}
@@ -0,0 +1,203 @@
+package iofs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+func newZipFS(t *testing.T, files map[string]string) fs.FS {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		if !assert.Nil(t, err) {
+			return nil
+		}
+		_, _ = w.Write([]byte(content))
+	}
+	assert.Nil(t, writer.Close())
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.Nil(t, err)
+	return reader
+}
+
+func newDirFS(t *testing.T, files map[string]string) fs.FS {
+	dir := t.TempDir()
+	for name, content := range files {
+		location := path.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(path.Dir(location), 0755))
+		assert.Nil(t, ioutil.WriteFile(location, []byte(content), 0644))
+	}
+	return os.DirFS(dir)
+}
+
+func TestManager_List(t *testing.T) {
+	files := map[string]string{
+		"folder/a.txt":     "abc",
+		"folder/b.txt":     "xyz",
+		"folder/sub/c.txt": "123",
+		"root.txt":         "root",
+	}
+	var mapFS = fstest.MapFS{}
+	for name, content := range files {
+		mapFS[name] = &fstest.MapFile{Data: []byte(content), Mode: 0644}
+	}
+	var useCases = []struct {
+		description string
+		fs          fs.FS
+		URL         string
+		options     []storage.Option
+		expect      []string
+		hasError    bool
+	}{
+		{
+			description: "map fs folder",
+			fs:          mapFS,
+			URL:         "iofs://map/folder",
+			expect:      []string{"folder", "a.txt", "b.txt", "sub"},
+		},
+		{
+			description: "dir fs folder",
+			fs:          newDirFS(t, files),
+			URL:         "iofs://dir/folder/",
+			expect:      []string{"folder", "a.txt", "b.txt", "sub"},
+		},
+		{
+			description: "zip fs folder",
+			fs:          newZipFS(t, files),
+			URL:         "iofs://zip/folder",
+			expect:      []string{"folder", "a.txt", "b.txt", "sub"},
+		},
+		{
+			description: "root",
+			fs:          mapFS,
+			URL:         "iofs://map/",
+			expect:      []string{".", "folder", "root.txt"},
+		},
+		{
+			description: "file",
+			fs:          mapFS,
+			URL:         "iofs://map/folder/sub/c.txt",
+			expect:      []string{"c.txt"},
+		},
+		{
+			description: "paged folder",
+			fs:          mapFS,
+			URL:         "iofs://map/folder",
+			options:     []storage.Option{*option.NewPage(2, 2)},
+			expect:      []string{"folder", "b.txt"},
+		},
+		{
+			description: "missing",
+			fs:          mapFS,
+			URL:         "iofs://map/missing",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		manager := newManager(useCase.fs)
+		objects, err := manager.List(context.Background(), useCase.URL, useCase.options...)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			assert.Equal(t, 404, manager.ErrorCode(err), useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual []string
+		for _, object := range objects {
+			actual = append(actual, object.Name())
+		}
+		if len(actual) > 1 {
+			sort.Strings(actual[1:])
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestManager_OpenURL(t *testing.T) {
+	mapFS := fstest.MapFS{"folder/a.txt": &fstest.MapFile{Data: []byte("abc")}}
+	otherFS := fstest.MapFS{"folder/a.txt": &fstest.MapFile{Data: []byte("other")}}
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		URL         string
+		options     []storage.Option
+		expect      string
+		hasError    bool
+	}{
+		{
+			description: "manager fs",
+			manager:     New(mapFS),
+			URL:         "iofs://map/folder/a.txt",
+			expect:      "abc",
+		},
+		{
+			description: "call fs",
+			manager:     New(mapFS),
+			URL:         "iofs://map/folder/a.txt",
+			options:     []storage.Option{otherFS},
+			expect:      "other",
+		},
+		{
+			description: "directory",
+			manager:     New(mapFS),
+			URL:         "iofs://map/folder",
+			hasError:    true,
+		},
+		{
+			description: "missing fs",
+			manager:     New(),
+			URL:         "iofs://map/folder/a.txt",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		reader, err := useCase.manager.OpenURL(context.Background(), useCase.URL, useCase.options...)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, useCase.description)
+		assert.Nil(t, reader.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, string(data), useCase.description)
+	}
+}
+
+func TestManager_Exists(t *testing.T) {
+	manager := newManager(fstest.MapFS{"folder/a.txt": &fstest.MapFile{Data: []byte("abc")}})
+	var useCases = []struct {
+		description string
+		URL         string
+		expect      bool
+	}{
+		{description: "file", URL: "iofs://map/folder/a.txt", expect: true},
+		{description: "folder", URL: "iofs://map/folder", expect: true},
+		{description: "missing", URL: "iofs://map/folder/b.txt", expect: false},
+	}
+	for _, useCase := range useCases {
+		actual, err := manager.Exists(context.Background(), useCase.URL)
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, useCase.expect, actual, useCase.description)
+	}
+	assert.NotNil(t, manager.Upload(context.Background(), "iofs://map/folder/b.txt", 0644, bytes.NewReader(nil)))
+	assert.NotNil(t, manager.Delete(context.Background(), "iofs://map/folder/a.txt"))
+}
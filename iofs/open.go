@@ -0,0 +1,36 @@
+package iofs
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/storage"
+	"io"
+)
+
+//Open returns a reader for supplied object
+func (s *manager) Open(ctx context.Context, object storage.Object, options ...storage.Option) (io.ReadCloser, error) {
+	return s.OpenURL(ctx, object.URL(), options...)
+}
+
+//OpenURL returns a reader for supplied URL
+func (s *manager) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	fsys, err := s.filesystem(options)
+	if err != nil {
+		return nil, err
+	}
+	name := namePath(URL)
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		_ = file.Close()
+		return nil, fmt.Errorf("%v: is directory", URL)
+	}
+	return file, nil
+}
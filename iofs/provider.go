@@ -0,0 +1,10 @@
+package iofs
+
+import (
+	"github.com/viant/afs/storage"
+)
+
+//Provider returns io/fs filesystem manager
+func Provider(options ...storage.Option) (storage.Manager, error) {
+	return New(options...), nil
+}
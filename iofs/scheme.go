@@ -0,0 +1,4 @@
+package iofs
+
+//Scheme represents io/fs filesystem URL scheme
+const Scheme = "iofs"
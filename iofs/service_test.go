@@ -0,0 +1,44 @@
+package iofs_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"io"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+func TestService_Copy(t *testing.T) {
+	ctx := context.Background()
+	mapFS := fstest.MapFS{
+		"data/a.txt":     &fstest.MapFile{Data: []byte("abc")},
+		"data/sub/b.txt": &fstest.MapFile{Data: []byte("xyz")},
+	}
+	fs := afs.New()
+	_, err := fs.List(ctx, "iofs://mount/data", mapFS)
+	if !assert.Nil(t, err) {
+		return
+	}
+	err = fs.Copy(ctx, "iofs://mount/data", "mem://localhost/copy")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for URL, expect := range map[string]string{
+		"mem://localhost/copy/a.txt":     "abc",
+		"mem://localhost/copy/sub/b.txt": "xyz",
+	} {
+		data, err := fs.DownloadWithURL(ctx, URL)
+		assert.Nil(t, err, URL)
+		assert.EqualValues(t, expect, string(data), URL)
+	}
+
+	var visited []string
+	err = fs.Walk(ctx, "iofs://mount/data", func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+		visited = append(visited, parent+"/"+info.Name())
+		return true, nil
+	})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"/a.txt", "/sub", "sub/b.txt"}, visited)
+}
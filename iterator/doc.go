@@ -0,0 +1,2 @@
+//Package iterator defines streaming listing helpers and fallback storage.IterLister for storage.Lister based managers
+package iterator
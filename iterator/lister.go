@@ -0,0 +1,36 @@
+package iterator
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+)
+
+type lister struct {
+	storage.Lister
+}
+
+//ListIter lists all objects with underlying lister and returns name ordered iterator
+func (l *lister) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	token, err := Continuation(options)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := l.List(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	var entries = make([]storage.Object, 0, len(objects))
+	for i := range objects {
+		if objects[i].IsDir() && url.Equals(URL, objects[i].URL()) {
+			continue
+		}
+		entries = append(entries, objects[i])
+	}
+	return NewSorted(entries, token), nil
+}
+
+//New creates fallback streaming lister for managers without native support, objects are listed at once
+func New(manager storage.Lister) storage.IterLister {
+	return &lister{Lister: manager}
+}
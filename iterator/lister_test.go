@@ -0,0 +1,39 @@
+package iterator_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"strings"
+	"testing"
+)
+
+func TestLister_ListIter(t *testing.T) {
+	ctx := context.Background()
+	manager := mem.New()
+	baseURL := "mem://localhost/iterator_lister"
+	for _, name := range []string{"c.txt", "a.txt", "b.txt", "sub/d.txt"} {
+		if !assert.Nil(t, manager.Upload(ctx, baseURL+"/"+name, 0644, strings.NewReader(name))) {
+			return
+		}
+	}
+	lister := iterator.New(manager)
+	iter, err := lister.ListIter(ctx, baseURL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	names, token := drain(iter, 2)
+	assert.EqualValues(t, []string{"a.txt", "b.txt"}, names)
+	iter, err = lister.ListIter(ctx, baseURL, option.NewContinuation(token))
+	if !assert.Nil(t, err) {
+		return
+	}
+	names, _ = drain(iter, 10)
+	assert.EqualValues(t, []string{"c.txt", "sub"}, names)
+	assert.Nil(t, iter.Err())
+
+	_, err = lister.ListIter(ctx, baseURL, option.NewContinuation("invalid token"))
+	assert.NotNil(t, err)
+}
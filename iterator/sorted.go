@@ -0,0 +1,65 @@
+package iterator
+
+import (
+	"github.com/viant/afs/storage"
+	"sort"
+)
+
+//sorted represents name ordered iterator, continuation resumes after the last returned name
+type sorted struct {
+	objects []storage.Object
+	index   int
+	offset  int64
+}
+
+func (s *sorted) Next() bool {
+	if s.index+1 >= len(s.objects) {
+		s.index = len(s.objects)
+		return false
+	}
+	s.index++
+	s.offset++
+	return true
+}
+
+func (s *sorted) Object() storage.Object {
+	if s.index < 0 || s.index >= len(s.objects) {
+		return nil
+	}
+	return s.objects[s.index]
+}
+
+func (s *sorted) Err() error {
+	return nil
+}
+
+func (s *sorted) Token() string {
+	object := s.Object()
+	if object == nil {
+		return ""
+	}
+	return (&Token{Offset: s.offset, Name: object.Name()}).Encode()
+}
+
+func (s *sorted) Close() error {
+	s.objects = nil
+	return nil
+}
+
+//NewSorted creates iterator over objects ordered by name, objects with name up to token name are skipped
+func NewSorted(objects []storage.Object, token *Token) storage.Iterator {
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Name() < objects[j].Name()
+	})
+	result := &sorted{index: -1}
+	if token == nil || token.Name == "" {
+		result.objects = objects
+		return result
+	}
+	start := sort.Search(len(objects), func(i int) bool {
+		return objects[i].Name() > token.Name
+	})
+	result.objects = objects[start:]
+	result.offset = token.Offset
+	return result
+}
@@ -0,0 +1,76 @@
+package iterator_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/storage"
+	"testing"
+	"time"
+)
+
+func newObjects(names ...string) []storage.Object {
+	var result = make([]storage.Object, 0, len(names))
+	for _, name := range names {
+		result = append(result, object.New("mem://localhost/folder/"+name, file.NewInfo(name, 1, 0644, time.Now(), false), nil))
+	}
+	return result
+}
+
+func drain(iter storage.Iterator, limit int) ([]string, string) {
+	var names []string
+	token := ""
+	for len(names) < limit && iter.Next() {
+		names = append(names, iter.Object().Name())
+		token = iter.Token()
+	}
+	return names, token
+}
+
+func TestNewSorted(t *testing.T) {
+	var useCases = []struct {
+		description string
+		names       []string
+		limit       int
+		expect      []string
+		expectRest  []string
+	}{
+		{
+			description: "all entries",
+			names:       []string{"c", "a", "b"},
+			limit:       10,
+			expect:      []string{"a", "b", "c"},
+		},
+		{
+			description: "resumed entries",
+			names:       []string{"d", "c", "a", "b"},
+			limit:       2,
+			expect:      []string{"a", "b"},
+			expectRest:  []string{"c", "d"},
+		},
+		{
+			description: "empty",
+			limit:       10,
+		},
+	}
+	for _, useCase := range useCases {
+		iter := iterator.NewSorted(newObjects(useCase.names...), nil)
+		actual, token := drain(iter, useCase.limit)
+		assert.Nil(t, iter.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		if len(useCase.expectRest) == 0 {
+			continue
+		}
+		decoded, err := iterator.DecodeToken(token)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, len(useCase.expect), decoded.Offset, useCase.description)
+		rest, _ := drain(iterator.NewSorted(newObjects(useCase.names...), decoded), useCase.limit)
+		assert.EqualValues(t, useCase.expectRest, rest, useCase.description)
+	}
+
+	_, err := iterator.DecodeToken("not a token")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,111 @@
+package iterator
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"sync"
+)
+
+const streamBufferSize = 64
+
+//Producer emits objects in a stable order, emit returns false once iterator has been closed
+type Producer func(ctx context.Context, emit func(object storage.Object) bool) error
+
+//stream represents producer backed iterator, continuation resumes after the number of returned objects
+type stream struct {
+	objects chan storage.Object
+	done    chan struct{}
+	closer  sync.Once
+	object  storage.Object
+	offset  int64
+	err     error
+	errMux  sync.Mutex
+}
+
+func (s *stream) Next() bool {
+	object, ok := <-s.objects
+	if !ok {
+		s.object = nil
+		return false
+	}
+	s.object = object
+	s.offset++
+	return true
+}
+
+func (s *stream) Object() storage.Object {
+	return s.object
+}
+
+func (s *stream) Err() error {
+	s.errMux.Lock()
+	defer s.errMux.Unlock()
+	return s.err
+}
+
+func (s *stream) setErr(err error) {
+	s.errMux.Lock()
+	s.err = err
+	s.errMux.Unlock()
+}
+
+func (s *stream) Token() string {
+	if s.object == nil {
+		return ""
+	}
+	return (&Token{Offset: s.offset, Name: s.object.Name()}).Encode()
+}
+
+//Close stops producer
+func (s *stream) Close() error {
+	s.closer.Do(func() {
+		close(s.done)
+	})
+	for range s.objects {
+	}
+	return nil
+}
+
+//NewStream creates an iterator running producer in the background, objects up to token offset are skipped,
+//ErrTokenExpired is reported if the last skipped object name does not match token name
+func NewStream(ctx context.Context, producer Producer, token *Token) storage.Iterator {
+	if token == nil {
+		token = &Token{}
+	}
+	result := &stream{
+		objects: make(chan storage.Object, streamBufferSize),
+		done:    make(chan struct{}),
+		offset:  token.Offset,
+	}
+	go func() {
+		defer close(result.objects)
+		var skipped int64
+		err := producer(ctx, func(object storage.Object) bool {
+			if skipped < token.Offset {
+				skipped++
+				if skipped == token.Offset && token.Name != "" && object.Name() != token.Name {
+					result.setErr(ErrTokenExpired)
+					return false
+				}
+				return true
+			}
+			select {
+			case result.objects <- object:
+				return true
+			case <-result.done:
+				return false
+			case <-ctx.Done():
+				result.setErr(ctx.Err())
+				return false
+			}
+		})
+		if err != nil {
+			result.setErr(err)
+			return
+		}
+		if result.Err() == nil && skipped < token.Offset {
+			result.setErr(ErrTokenExpired)
+		}
+	}()
+	return result
+}
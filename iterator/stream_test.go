@@ -0,0 +1,107 @@
+package iterator_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/storage"
+	"testing"
+)
+
+func producer(objects []storage.Object, err error) iterator.Producer {
+	return func(ctx context.Context, emit func(object storage.Object) bool) error {
+		for _, object := range objects {
+			if !emit(object) {
+				return nil
+			}
+		}
+		return err
+	}
+}
+
+func TestNewStream(t *testing.T) {
+	var useCases = []struct {
+		description string
+		source      []string
+		resumed     []string
+		limit       int
+		err         error
+		expect      []string
+		expectRest  []string
+		expectErr   error
+	}{
+		{
+			description: "all entries",
+			source:      []string{"c", "a", "b"},
+			limit:       10,
+			expect:      []string{"c", "a", "b"},
+		},
+		{
+			description: "resumed entries",
+			source:      []string{"c", "a", "b", "d"},
+			limit:       2,
+			expect:      []string{"c", "a"},
+			expectRest:  []string{"b", "d"},
+		},
+		{
+			description: "changed listing",
+			source:      []string{"c", "a", "b", "d"},
+			resumed:     []string{"x", "c", "b", "d"},
+			limit:       2,
+			expect:      []string{"c", "a"},
+			expectErr:   iterator.ErrTokenExpired,
+		},
+		{
+			description: "producer error",
+			source:      []string{"a"},
+			limit:       10,
+			err:         errors.New("test error"),
+			expect:      []string{"a"},
+			expectErr:   errors.New("test error"),
+		},
+	}
+	ctx := context.Background()
+	for _, useCase := range useCases {
+		iter := iterator.NewStream(ctx, producer(newObjects(useCase.source...), useCase.err), nil)
+		actual, token := drain(iter, useCase.limit)
+		assert.Nil(t, iter.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		if useCase.resumed == nil {
+			assert.EqualValues(t, useCase.expectErr, iter.Err(), useCase.description)
+		}
+		if len(useCase.expectRest) == 0 && useCase.resumed == nil {
+			continue
+		}
+		decoded, err := iterator.DecodeToken(token)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		source := useCase.source
+		if useCase.resumed != nil {
+			source = useCase.resumed
+		}
+		resumed := iterator.NewStream(ctx, producer(newObjects(source...), nil), decoded)
+		rest, _ := drain(resumed, useCase.limit)
+		assert.EqualValues(t, useCase.expectRest, rest, useCase.description)
+		assert.EqualValues(t, useCase.expectErr, resumed.Err(), useCase.description)
+		assert.Nil(t, resumed.Close(), useCase.description)
+	}
+}
+
+func TestStream_Close(t *testing.T) {
+	produced := 0
+	iter := iterator.NewStream(context.Background(), func(ctx context.Context, emit func(object storage.Object) bool) error {
+		for {
+			produced++
+			if !emit(newObjects("a")[0]) {
+				return nil
+			}
+		}
+	}, nil)
+	assert.True(t, iter.Next())
+	assert.Nil(t, iter.Close())
+	assert.False(t, iter.Next())
+	assert.Nil(t, iter.Err())
+	assert.True(t, produced > 0)
+}
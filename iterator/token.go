@@ -0,0 +1,48 @@
+package iterator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+)
+
+//ErrTokenExpired represents continuation token error for listing that has changed since token was issued
+var ErrTokenExpired = errors.New("continuation token has expired: listing has changed")
+
+//Token represents listing position: number of returned entries and the last returned entry name
+type Token struct {
+	Offset int64  `json:"o,omitempty"`
+	Name   string `json:"n,omitempty"`
+}
+
+//Encode returns opaque token representation
+func (t *Token) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+//DecodeToken decodes opaque token, empty token represents listing start
+func DecodeToken(encoded string) (*Token, error) {
+	token := &Token{}
+	if encoded == "" {
+		return token, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, token)
+	}
+	if err != nil || token.Offset < 0 {
+		return nil, fmt.Errorf("invalid continuation token: %v", encoded)
+	}
+	return token, nil
+}
+
+//Continuation returns decoded option.Continuation token
+func Continuation(options []storage.Option) (*Token, error) {
+	continuation := &option.Continuation{}
+	option.Assign(options, &continuation)
+	return DecodeToken(continuation.Token)
+}
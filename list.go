@@ -3,6 +3,7 @@ package afs
 import (
 	"context"
 	"github.com/viant/afs/file"
+	"github.com/viant/afs/iterator"
 	"github.com/viant/afs/matcher"
 	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
@@ -10,20 +11,39 @@ import (
 	"sync"
 )
 
+//defaultListWorkers represents max number of concurrent recursive folder listings
+const defaultListWorkers = 8
+
 func (s *service) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
 	URL = url.Normalize(URL, file.Scheme)
 	recursive := &option.Recursive{}
-	options, _ = option.Assign(options, &recursive)
+	parallel := &option.Parallel{Workers: defaultListWorkers}
+	options, _ = option.Assign(options, &recursive, &parallel)
 	manager, err := s.manager(ctx, URL, options)
 	if err != nil {
 		return nil, err
 	}
 	var result = make([]storage.Object, 0)
 	objects := storage.NewObjects(&result)
-	return result, list(ctx, manager, URL, recursive.Flag, options, objects)
+	workers := make(chan bool, parallel.Workers)
+	return result, list(ctx, manager, URL, recursive.Flag, options, objects, workers)
+}
+
+//ListIter returns an iterator over URL entries, managers without native storage.IterLister support are listed at once
+func (s *service) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	URL = url.Normalize(URL, file.Scheme)
+	manager, err := s.manager(ctx, URL, options)
+	if err != nil {
+		return nil, err
+	}
+	if lister, ok := manager.(storage.IterLister); ok {
+		return lister.ListIter(ctx, URL, options...)
+	}
+	return iterator.New(manager).ListIter(ctx, URL, options...)
 }
 
-func list(ctx context.Context, lister storage.Lister, URL string, recursive bool, options []storage.Option, result *storage.Objects) error {
+//list appends URL entries to result, sub folders are listed in a new goroutine while workers has capacity, otherwise inline
+func list(ctx context.Context, lister storage.Lister, URL string, recursive bool, options []storage.Option, result *storage.Objects, workers chan bool) error {
 	objects, err := lister.List(ctx, URL, options...)
 	if err != nil {
 		return err
@@ -59,20 +79,33 @@ func list(ctx context.Context, lister storage.Lister, URL string, recursive bool
 			}
 		}
 		wg := &sync.WaitGroup{}
+		errs := make([]error, len(dirs))
 		for i := 0; i < len(dirs); i++ {
 			if i == 0 && url.Equals(URL, dirs[i].URL()) {
 				continue
 			}
-			wg.Add(1)
-			go func(index int) {
-				defer wg.Done()
-				result.Append(dirs[index])
-				if lErr := list(ctx, lister, dirs[index].URL(), recursive, options, result); lErr != nil {
-					err = lErr
-				}
-			}(i)
+			select {
+			case workers <- true:
+				wg.Add(1)
+				go func(index int) {
+					defer func() {
+						<-workers
+						wg.Done()
+					}()
+					result.Append(dirs[index])
+					errs[index] = list(ctx, lister, dirs[index].URL(), recursive, options, result, workers)
+				}(i)
+			default:
+				result.Append(dirs[i])
+				errs[i] = list(ctx, lister, dirs[i].URL(), recursive, options, result, workers)
+			}
 		}
 		wg.Wait()
+		for _, lErr := range errs {
+			if lErr != nil {
+				return lErr
+			}
+		}
 	}
 	return err
 }
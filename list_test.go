@@ -6,10 +6,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/afs/asset"
 	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
 	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/fs"
 	"os"
 	"path"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 )
 
 func TestService_List(t *testing.T) {
@@ -77,3 +84,121 @@ func TestService_List(t *testing.T) {
 
 	}
 }
+
+func TestService_ListIter(t *testing.T) {
+	ctx := context.Background()
+	mapFS := fstest.MapFS{
+		"data/a.txt":     {Data: []byte("abc")},
+		"data/b.txt":     {Data: []byte("abc")},
+		"data/c/d.txt":   {Data: []byte("abc")},
+		"data/e.txt":     {Data: []byte("abc")},
+		"data/f/g/h.txt": {Data: []byte("abc")},
+	}
+	memURL := "mem://localhost/service_list_iter"
+	service := New()
+	for name := range mapFS {
+		if !assert.Nil(t, service.Upload(ctx, memURL+"/"+name, 0644, strings.NewReader("abc"))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		URL         string
+		options     []storage.Option
+		pageSize    int
+		expect      []string
+	}{
+		{
+			description: "native iterator",
+			URL:         memURL + "/data",
+			pageSize:    2,
+			expect:      []string{"a.txt", "b.txt", "c", "e.txt", "f"},
+		},
+		{
+			description: "list based iterator",
+			URL:         "iofs://localhost/data",
+			options:     []storage.Option{fs.FS(mapFS)},
+			pageSize:    2,
+			expect:      []string{"a.txt", "b.txt", "c", "e.txt", "f"},
+		},
+		{
+			description: "list based iterator with matcher",
+			URL:         "iofs://localhost/data",
+			options:     []storage.Option{fs.FS(mapFS), option.Match(func(parent string, info os.FileInfo) bool { return !info.IsDir() })},
+			pageSize:    1,
+			expect:      []string{"a.txt", "b.txt", "e.txt"},
+		},
+	}
+
+	for _, useCase := range useCases {
+		var actual []string
+		token := ""
+		for i := 0; i < 10; i++ {
+			iter, err := service.ListIter(ctx, useCase.URL, append(useCase.options, option.NewContinuation(token))...)
+			if !assert.Nil(t, err, useCase.description) {
+				break
+			}
+			count := 0
+			for count < useCase.pageSize && iter.Next() {
+				actual = append(actual, iter.Object().Name())
+				token = iter.Token()
+				count++
+			}
+			assert.Nil(t, iter.Err(), useCase.description)
+			assert.Nil(t, iter.Close(), useCase.description)
+			if count < useCase.pageSize {
+				break
+			}
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestService_List_Workers(t *testing.T) {
+	ctx := context.Background()
+	baseURL := "mem://localhost/service_list_workers"
+	service := New()
+	for i := 0; i < 20; i++ {
+		if !assert.Nil(t, service.Upload(ctx, fmt.Sprintf("%v/d%02d/s/f.txt", baseURL, i), 0644, strings.NewReader("abc"))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		workers     int
+	}{
+		{description: "single worker", workers: 1},
+		{description: "bounded workers", workers: 3},
+		{description: "no workers", workers: 0},
+	}
+	for _, useCase := range useCases {
+		lister := &concurrentLister{Lister: mem.Singleton()}
+		var result = make([]storage.Object, 0)
+		err := list(ctx, lister, baseURL, true, nil, storage.NewObjects(&result), make(chan bool, useCase.workers))
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, 60, len(result), useCase.description)
+		assert.True(t, lister.max <= int32(useCase.workers)+1, fmt.Sprintf("%v: %v", useCase.description, lister.max))
+	}
+}
+
+//concurrentLister records max number of concurrent List calls
+type concurrentLister struct {
+	storage.Lister
+	active int32
+	max    int32
+}
+
+func (l *concurrentLister) List(ctx context.Context, URL string, options ...storage.Option) ([]storage.Object, error) {
+	active := atomic.AddInt32(&l.active, 1)
+	defer atomic.AddInt32(&l.active, -1)
+	for {
+		max := atomic.LoadInt32(&l.max)
+		if active <= max || atomic.CompareAndSwapInt32(&l.max, max, active) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return l.Lister.List(ctx, URL, options...)
+}
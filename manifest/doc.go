@@ -0,0 +1,2 @@
+//Package manifest generates and verifies checksum manifests (sha256sum compatible or JSON) for any storage location
+package manifest
@@ -0,0 +1,98 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	//FormatSum represents sha256sum (md5sum) compatible text format
+	FormatSum = "sum"
+	//FormatJSON represents JSON format
+	FormatJSON = "json"
+)
+
+//Entry represents manifest file entry
+type Entry struct {
+	Path     string `json:"path"`
+	Checksum string `json:"checksum"`
+	Size     int64  `json:"size,omitempty"`
+}
+
+//Manifest represents checksum manifest
+type Manifest struct {
+	Algorithm string   `json:"algorithm"`
+	Entries   []*Entry `json:"entries"`
+}
+
+//Index returns entries indexed by path
+func (m *Manifest) Index() map[string]*Entry {
+	var result = make(map[string]*Entry, len(m.Entries))
+	for _, entry := range m.Entries {
+		result[entry.Path] = entry
+	}
+	return result
+}
+
+//Sort sorts entries by path
+func (m *Manifest) Sort() {
+	sort.Slice(m.Entries, func(i, j int) bool {
+		return m.Entries[i].Path < m.Entries[j].Path
+	})
+}
+
+//Encode writes manifest in supplied format
+func (m *Manifest) Encode(writer io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case FormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(m)
+	case FormatSum, "":
+		buffer := bufio.NewWriter(writer)
+		for _, entry := range m.Entries {
+			if _, err := fmt.Fprintf(buffer, "%v  %v\n", entry.Checksum, entry.Path); err != nil {
+				return err
+			}
+		}
+		return buffer.Flush()
+	}
+	return fmt.Errorf("unsupported manifest format: %v", format)
+}
+
+//Decode decodes JSON or sum formatted manifest, sum format does not carry algorithm
+func Decode(data []byte) (*Manifest, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		result := &Manifest{}
+		if err := json.Unmarshal(trimmed, result); err != nil {
+			return nil, fmt.Errorf("invalid JSON manifest: %w", err)
+		}
+		return result, nil
+	}
+	result := &Manifest{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		index := strings.Index(line, " ")
+		if index == -1 || index+1 >= len(line) {
+			return nil, fmt.Errorf("invalid manifest line %v: %v", lineNumber, line)
+		}
+		location := line[index+1:]
+		if location[0] == ' ' || location[0] == '*' { //text or binary mode marker
+			location = location[1:]
+		}
+		if location == "" {
+			return nil, fmt.Errorf("invalid manifest line %v: %v", lineNumber, line)
+		}
+		result.Entries = append(result.Entries, &Entry{Path: location, Checksum: strings.ToLower(line[:index])})
+	}
+	return result, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package manifest
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	var useCases = []struct {
+		description string
+		data        string
+		expect      *Manifest
+		hasError    bool
+	}{
+		{
+			description: "sum format",
+			data:        "ABC123  a.txt\nabc456 *b/c d.txt\n\n",
+			expect: &Manifest{Entries: []*Entry{
+				{Path: "a.txt", Checksum: "abc123"},
+				{Path: "b/c d.txt", Checksum: "abc456"},
+			}},
+		},
+		{
+			description: "json format",
+			data:        `{"algorithm":"md5","entries":[{"path":"a.txt","checksum":"abc","size":3}]}`,
+			expect:      &Manifest{Algorithm: "md5", Entries: []*Entry{{Path: "a.txt", Checksum: "abc", Size: 3}}},
+		},
+		{
+			description: "invalid sum line",
+			data:        "abc123\n",
+			hasError:    true,
+		},
+		{
+			description: "invalid json",
+			data:        `{"entries":`,
+			hasError:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		actual, err := Decode([]byte(useCase.data))
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestManifest_Encode(t *testing.T) {
+	manifest := &Manifest{Algorithm: "sha256", Entries: []*Entry{
+		{Path: "b.txt", Checksum: "02", Size: 2},
+		{Path: "a.txt", Checksum: "01", Size: 1},
+	}}
+	manifest.Sort()
+	var useCases = []struct {
+		description string
+		format      string
+		expect      string
+		hasError    bool
+	}{
+		{description: "sum format", format: FormatSum, expect: "01  a.txt\n02  b.txt\n"},
+		{description: "default format", expect: "01  a.txt\n02  b.txt\n"},
+		{description: "json format", format: FormatJSON, expect: "{\n  \"algorithm\": \"sha256\",\n  \"entries\": [\n    {\n      \"path\": \"a.txt\",\n      \"checksum\": \"01\",\n      \"size\": 1\n    },\n    {\n      \"path\": \"b.txt\",\n      \"checksum\": \"02\",\n      \"size\": 2\n    }\n  ]\n}\n"},
+		{description: "unsupported format", format: "xml", hasError: true},
+	}
+	for _, useCase := range useCases {
+		buffer := new(bytes.Buffer)
+		err := manifest.Encode(buffer, useCase.format)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, buffer.String(), useCase.description)
+		decoded, err := Decode(buffer.Bytes())
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, len(manifest.Entries), len(decoded.Entries), useCase.description)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package manifest
+
+//Report represents manifest verification report
+type Report struct {
+	Missing   []string //files listed in manifest but not found
+	Extra     []string //files found but not listed in manifest
+	Corrupted []string //files with checksum different than in manifest
+}
+
+//Valid returns true if there are no missing, extra or corrupted files
+func (r *Report) Valid() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Corrupted) == 0
+}
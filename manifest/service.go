@@ -0,0 +1,144 @@
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"github.com/viant/afs"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"path"
+	"strings"
+)
+
+//Build lists URL recursively (honoring matcher options) and returns manifest of all files
+func Build(ctx context.Context, fs afs.Service, URL string, options ...storage.Option) (*Manifest, error) {
+	return build(ctx, fs, URL, "", algorithm(options), options)
+}
+
+//Generate builds URL manifest and uploads it to destURL, format is taken from option.Manifest or destURL extension (.json)
+func Generate(ctx context.Context, fs afs.Service, URL, destURL string, options ...storage.Option) (*Manifest, error) {
+	manifest, err := build(ctx, fs, URL, destURL, algorithm(options), options)
+	if err != nil {
+		return nil, err
+	}
+	buffer := new(bytes.Buffer)
+	if err = manifest.Encode(buffer, format(destURL, options)); err != nil {
+		return nil, err
+	}
+	return manifest, fs.Upload(ctx, destURL, file.DefaultFileOsMode, buffer)
+}
+
+//Load loads manifest, sum format algorithm is taken from option.Manifest or inferred from checksum length
+func Load(ctx context.Context, fs afs.Service, URL string, options ...storage.Option) (*Manifest, error) {
+	data, err := fs.DownloadWithURL(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Algorithm == "" {
+		manifestOption := &option.Manifest{}
+		option.Assign(options, &manifestOption)
+		manifest.Algorithm = manifestOption.Algorithm
+	}
+	if manifest.Algorithm == "" {
+		manifest.Algorithm = inferAlgorithm(manifest.Entries)
+	}
+	return manifest, nil
+}
+
+//Verify compares URL files with manifestURL manifest and reports missing, extra and corrupted files
+func Verify(ctx context.Context, fs afs.Service, URL, manifestURL string, options ...storage.Option) (*Report, error) {
+	expected, err := Load(ctx, fs, manifestURL, options...)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := build(ctx, fs, URL, manifestURL, expected.Algorithm, options)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	actualIndex := actual.Index()
+	expected.Sort()
+	for _, entry := range expected.Entries {
+		candidate, ok := actualIndex[entry.Path]
+		if !ok {
+			report.Missing = append(report.Missing, entry.Path)
+			continue
+		}
+		if !strings.EqualFold(candidate.Checksum, entry.Checksum) {
+			report.Corrupted = append(report.Corrupted, entry.Path)
+		}
+	}
+	expectedIndex := expected.Index()
+	for _, entry := range actual.Entries {
+		if _, ok := expectedIndex[entry.Path]; !ok {
+			report.Extra = append(report.Extra, entry.Path)
+		}
+	}
+	return report, nil
+}
+
+func build(ctx context.Context, fs afs.Service, URL, manifestURL, algorithm string, options []storage.Option) (*Manifest, error) {
+	if _, err := checksum.New(algorithm); err != nil {
+		return nil, err
+	}
+	if manifestURL != "" {
+		manifestURL = url.Normalize(manifestURL, file.Scheme)
+	}
+	result := &Manifest{Algorithm: strings.ToLower(algorithm)}
+	objects, err := afs.Index(ctx, fs, url.Normalize(URL, file.Scheme), options...)
+	if err != nil {
+		return nil, err
+	}
+	for relative, object := range objects {
+		if object.IsDir() || (manifestURL != "" && url.Equals(object.URL(), manifestURL)) {
+			continue
+		}
+		digest, err := fs.Checksum(ctx, object.URL(), algorithm, options...)
+		if err != nil {
+			return nil, err
+		}
+		result.Entries = append(result.Entries, &Entry{Path: relative, Checksum: hex.EncodeToString(digest), Size: object.Size()})
+	}
+	result.Sort()
+	return result, nil
+}
+
+func algorithm(options []storage.Option) string {
+	manifestOption := &option.Manifest{}
+	if _, ok := option.Assign(options, &manifestOption); ok && manifestOption.Algorithm != "" {
+		return manifestOption.Algorithm
+	}
+	return checksum.SHA256
+}
+
+func format(URL string, options []storage.Option) string {
+	manifestOption := &option.Manifest{}
+	if _, ok := option.Assign(options, &manifestOption); ok && manifestOption.Format != "" {
+		return manifestOption.Format
+	}
+	if strings.ToLower(path.Ext(URL)) == ".json" {
+		return FormatJSON
+	}
+	return FormatSum
+}
+
+func inferAlgorithm(entries []*Entry) string {
+	if len(entries) == 0 {
+		return checksum.SHA256
+	}
+	switch len(entries[0].Checksum) {
+	case 2 * 16:
+		return checksum.MD5
+	case 2 * 4:
+		return checksum.CRC32C
+	}
+	return checksum.SHA256
+}
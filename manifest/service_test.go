@@ -0,0 +1,141 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	fs := afs.New()
+	_, filename, _, _ := runtime.Caller(0)
+	baseDir, _ := path.Split(filename)
+	baseDir = path.Dir(strings.TrimRight(baseDir, "/"))
+	tempDir := path.Join(os.TempDir(), "afs_manifest")
+	defer func() { _ = fs.Delete(ctx, tempDir) }()
+	ignore, _ := matcher.NewIgnore([]string{"*.log"})
+
+	var useCases = []struct {
+		description   string
+		URL           string
+		manifestURL   string
+		verifyURL     string
+		assets        map[string]string
+		changes       map[string]string
+		options       []storage.Option
+		expectEntries []string
+		expectFormat  string
+		expect        *Report
+	}{
+		{
+			description:   "mem sum manifest with missing, extra and corrupted files",
+			URL:           "mem://localhost/manifest/src01",
+			manifestURL:   "mem://localhost/manifest/SHA256SUMS",
+			assets:        map[string]string{"a.txt": "abc", "b/c.txt": "xyz", "d.txt": "123"},
+			changes:       map[string]string{"a.txt": "abcd", "b/c.txt": "", "e.txt": "new"},
+			expectEntries: []string{"a.txt", "b/c.txt", "d.txt"},
+			expectFormat:  FormatSum,
+			expect:        &Report{Missing: []string{"b/c.txt"}, Extra: []string{"e.txt"}, Corrupted: []string{"a.txt"}},
+		},
+		{
+			description:   "file json manifest inside location",
+			URL:           path.Join(tempDir, "src02"),
+			manifestURL:   path.Join(tempDir, "src02", "manifest.json"),
+			assets:        map[string]string{"a.txt": "abc", "b/c.txt": "xyz"},
+			expectEntries: []string{"a.txt", "b/c.txt"},
+			expectFormat:  FormatJSON,
+			expect:        &Report{},
+		},
+		{
+			description:   "matcher with md5 algorithm",
+			URL:           "mem://localhost/manifest/src03",
+			manifestURL:   "mem://localhost/manifest/MD5SUMS",
+			assets:        map[string]string{"a.txt": "abc", "x.log": "log"},
+			changes:       map[string]string{"x.log": "changed"},
+			options:       []storage.Option{ignore, option.NewManifest("", "md5")},
+			expectEntries: []string{"a.txt"},
+			expectFormat:  FormatSum,
+			expect:        &Report{},
+		},
+		{
+			description:   "zip archive",
+			URL:           fmt.Sprintf("file:%v/zip/test/test.zip/zip://localhost/test", baseDir),
+			manifestURL:   "mem://localhost/manifest/zip.json",
+			expectEntries: []string{"asset1.txt", "asset2.txt", "folder1/res.txt", "folder2/res1.txt"},
+			expectFormat:  FormatJSON,
+			expect:        &Report{},
+		},
+		{
+			description:   "zip archive manifest verified against tar archive",
+			URL:           fmt.Sprintf("file:%v/zip/test/test.zip/zip://localhost/test", baseDir),
+			manifestURL:   "mem://localhost/manifest/ZIPSUMS",
+			verifyURL:     fmt.Sprintf("file:%v/tar/test/test.tar/tar://localhost/test", baseDir),
+			expectEntries: []string{"asset1.txt", "asset2.txt", "folder1/res.txt", "folder2/res1.txt"},
+			expectFormat:  FormatSum,
+			expect:        &Report{},
+		},
+	}
+
+	for _, useCase := range useCases {
+		if !assert.Nil(t, apply(ctx, fs, useCase.URL, useCase.assets), useCase.description) {
+			continue
+		}
+		manifest, err := Generate(ctx, fs, useCase.URL, useCase.manifestURL, useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual []string
+		for _, entry := range manifest.Entries {
+			actual = append(actual, entry.Path)
+		}
+		assert.EqualValues(t, useCase.expectEntries, actual, useCase.description)
+		data, err := fs.DownloadWithURL(ctx, useCase.manifestURL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expectFormat == FormatJSON, strings.HasPrefix(string(data), "{"), useCase.description)
+
+		if !assert.Nil(t, apply(ctx, fs, useCase.URL, useCase.changes), useCase.description) {
+			continue
+		}
+		verifyURL := useCase.URL
+		if useCase.verifyURL != "" {
+			verifyURL = useCase.verifyURL
+		}
+		report, err := Verify(ctx, fs, verifyURL, useCase.manifestURL, useCase.options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, report, useCase.description)
+		assert.EqualValues(t, useCase.expect.Valid(), report.Valid(), useCase.description)
+	}
+}
+
+//apply uploads assets, empty content deletes asset
+func apply(ctx context.Context, fs afs.Service, baseURL string, assets map[string]string) error {
+	for name, content := range assets {
+		URL := url.Join(baseURL, name)
+		if content == "" {
+			if err := fs.Delete(ctx, URL); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fs.Upload(ctx, URL, file.DefaultFileOsMode, strings.NewReader(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package mem
+
+import (
+	"context"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+)
+
+//Checksum returns cached file content checksum
+func (m *manager) Checksum(ctx context.Context, URL string, algorithm string, options ...storage.Option) ([]byte, error) {
+	root := m.Root(ctx, URL)
+	if root == nil {
+		return nil, nil
+	}
+	_, location := url.Base(URL, Scheme)
+	file, err := root.File(location)
+	if err != nil {
+		return nil, err
+	}
+	return file.Checksum(algorithm)
+}
@@ -0,0 +1,48 @@
+package mem
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/checksum"
+	"strings"
+	"testing"
+)
+
+func TestManager_Checksum(t *testing.T) {
+	ctx := context.Background()
+	manager := newManager()
+	baseURL := "mem://localhost/checksum"
+	if !assert.Nil(t, manager.Upload(ctx, baseURL+"/asset.txt", 0644, strings.NewReader("test"))) {
+		return
+	}
+	md5Digest := md5.Sum([]byte("test"))
+	sha256Digest := sha256.Sum256([]byte("test"))
+
+	var useCases = []struct {
+		description string
+		URL         string
+		algorithm   string
+		expect      []byte
+		hasError    bool
+	}{
+		{description: "md5", URL: baseURL + "/asset.txt", algorithm: checksum.MD5, expect: md5Digest[:]},
+		{description: "cached md5", URL: baseURL + "/asset.txt", algorithm: "MD5", expect: md5Digest[:]},
+		{description: "sha256", URL: baseURL + "/asset.txt", algorithm: checksum.SHA256, expect: sha256Digest[:]},
+		{description: "missing file", URL: baseURL + "/missing.txt", algorithm: checksum.MD5, hasError: true},
+		{description: "directory", URL: baseURL, algorithm: checksum.MD5, hasError: true},
+		{description: "unsupported algorithm", URL: baseURL + "/asset.txt", algorithm: "sha1", hasError: true},
+	}
+	for _, useCase := range useCases {
+		actual, err := manager.Checksum(ctx, useCase.URL, useCase.algorithm)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
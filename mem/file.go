@@ -2,6 +2,7 @@ package mem
 
 import (
 	"bytes"
+	"github.com/viant/afs/checksum"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/object"
 	"github.com/viant/afs/option"
@@ -12,6 +13,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -24,6 +26,8 @@ type File struct {
 	uploadError   error
 	readerError   error
 	generation    int64
+	mux           sync.Mutex
+	checksums     map[string][]byte
 }
 
 //NewReader return new Reader
@@ -35,6 +39,25 @@ func (f *File) NewReader() io.ReadCloser {
 	return ioutil.NopCloser(reader)
 }
 
+//Checksum returns content checksum for supplied algorithm, computed checksum is cached
+func (f *File) Checksum(algorithm string) ([]byte, error) {
+	algorithm = strings.ToLower(algorithm)
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if result, ok := f.checksums[algorithm]; ok {
+		return result, nil
+	}
+	result, err := checksum.Compute(bytes.NewReader(f.content), algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if f.checksums == nil {
+		f.checksums = make(map[string][]byte)
+	}
+	f.checksums[algorithm] = result
+	return result, nil
+}
+
 //SetErrors sets test errors
 func (f *File) SetErrors(errors ...*option.Error) {
 	if len(errors) > 0 {
@@ -56,7 +79,7 @@ func NewFile(URL string, mode os.FileMode, content []byte, modTime time.Time) *F
 	baseURL, URLPath := Split(URL)
 	URL = url.Join(baseURL, URLPath)
 	_, name := path.Split(URLPath)
-	info := file.NewInfo(name, 0, mode, modTime, false)
+	info := file.NewInfo(name, int64(len(content)), mode, modTime, false)
 	result := &File{
 		content: content,
 	}
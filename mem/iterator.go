@@ -0,0 +1,43 @@
+package mem
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+)
+
+//ListIter returns name ordered iterator over folder entries, continuation token resumes after the last returned name
+func (m *manager) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	token, err := iterator.Continuation(options)
+	if err != nil {
+		return nil, err
+	}
+	root := m.Root(ctx, URL)
+	if root == nil {
+		return nil, fmt.Errorf("failed to lookup storage root: %v", URL)
+	}
+	_, location := url.Base(URL, Scheme)
+	object, err := root.Lookup(location, 0)
+	if err != nil {
+		return nil, err
+	}
+	if !object.IsDir() {
+		return iterator.NewSorted([]storage.Object{object}, token), nil
+	}
+	folder := &Folder{}
+	if err = object.Unwrap(&folder); err != nil {
+		return nil, err
+	}
+	match, _ := option.GetListOptions(options)
+	objects := folder.Objects()
+	var entries = make([]storage.Object, 0, len(objects))
+	for _, entry := range objects[1:] {
+		if match(location, entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return iterator.NewSorted(entries, token), nil
+}
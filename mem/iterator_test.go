@@ -0,0 +1,92 @@
+package mem
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"strings"
+	"testing"
+)
+
+func TestManager_ListIter(t *testing.T) {
+	ctx := context.Background()
+	manager := newManager()
+	baseURL := "mem://localhost/iter"
+	for _, name := range []string{"c.txt", "a.txt", "b.txt", "d/e.txt"} {
+		if !assert.Nil(t, manager.Upload(ctx, baseURL+"/"+name, 0644, strings.NewReader(name))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		URL         string
+		pageSize    int
+		modify      func()
+		expect      []string
+		hasError    bool
+	}{
+		{
+			description: "folder iterator",
+			URL:         baseURL,
+			pageSize:    10,
+			expect:      []string{"a.txt", "b.txt", "c.txt", "d"},
+		},
+		{
+			description: "paged folder iterator",
+			URL:         baseURL,
+			pageSize:    3,
+			expect:      []string{"a.txt", "b.txt", "c.txt", "d"},
+		},
+		{
+			description: "paged folder iterator with concurrent modification",
+			URL:         baseURL,
+			pageSize:    2,
+			modify: func() {
+				_ = manager.Delete(ctx, baseURL+"/a.txt")
+			},
+			expect: []string{"a.txt", "b.txt", "c.txt", "d"},
+		},
+		{
+			description: "file iterator",
+			URL:         baseURL + "/d/e.txt",
+			pageSize:    1,
+			expect:      []string{"e.txt"},
+		},
+		{
+			description: "missing URL",
+			URL:         baseURL + "/missing",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		var actual []string
+		token := ""
+		for i := 0; i < 10; i++ {
+			iter, err := manager.ListIter(ctx, useCase.URL, option.NewContinuation(token))
+			if useCase.hasError {
+				assert.NotNil(t, err, useCase.description)
+				break
+			}
+			if !assert.Nil(t, err, useCase.description) {
+				break
+			}
+			count := 0
+			for count < useCase.pageSize && iter.Next() {
+				actual = append(actual, iter.Object().Name())
+				token = iter.Token()
+				count++
+			}
+			assert.Nil(t, iter.Close(), useCase.description)
+			if count < useCase.pageSize {
+				break
+			}
+			if useCase.modify != nil {
+				useCase.modify()
+				useCase.modify = nil
+			}
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
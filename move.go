@@ -15,7 +15,8 @@ func (s *service) Move(ctx context.Context, sourceURL, destURL string, options .
 	sourceOptions := option.NewSource()
 	destOptions := option.NewDest()
 	option.Assign(options, &sourceOptions, &destOptions)
-	if url.IsSchemeEquals(sourceURL, destURL) {
+	//extension URL mover (i.e. zip, tar) moves entries only within the same extended resource
+	if url.IsSchemeEquals(sourceURL, destURL) && url.SchemeExtensionURL(sourceURL) == url.SchemeExtensionURL(destURL) {
 		if sourceManager, err := s.manager(ctx, sourceURL, *sourceOptions); err == nil {
 			if mover, ok := sourceManager.(storage.Mover); ok {
 				if !s.IsAuthChanged(ctx, sourceManager, sourceURL, *destOptions) {
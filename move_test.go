@@ -10,6 +10,7 @@ import (
 	"github.com/viant/afs/url"
 	"os"
 	"path"
+	"strings"
 	"testing"
 )
 
@@ -122,3 +123,64 @@ func TestService_Move(t *testing.T) {
 	}
 
 }
+
+func TestService_Move_Archive(t *testing.T) {
+
+	baseDir := os.TempDir()
+	ctx := context.Background()
+
+	var useCases = []struct {
+		description string
+		archives    []string
+		asset       string
+		source      string
+		dest        string
+		expect      map[string]string
+	}{
+		{
+			description: "move within zip archive",
+			archives:    []string{path.Join(baseDir, "service_move_archive_01.zip")},
+			asset:       "file:" + path.Join(baseDir, "service_move_archive_01.zip") + "/zip://localhost/folder1/asset1.txt",
+			source:      "file:" + path.Join(baseDir, "service_move_archive_01.zip") + "/zip://localhost/folder1",
+			dest:        "file:" + path.Join(baseDir, "service_move_archive_01.zip") + "/zip://localhost/folder2",
+			expect: map[string]string{
+				"file:" + path.Join(baseDir, "service_move_archive_01.zip") + "/zip://localhost/folder2/asset1.txt": "test 1",
+			},
+		},
+		{
+			description: "move file between tar archives",
+			archives:    []string{path.Join(baseDir, "service_move_archive_02.tar"), path.Join(baseDir, "service_move_archive_03.tar")},
+			asset:       "file:" + path.Join(baseDir, "service_move_archive_02.tar") + "/tar://localhost/folder1/asset1.txt",
+			source:      "file:" + path.Join(baseDir, "service_move_archive_02.tar") + "/tar://localhost/folder1/asset1.txt",
+			dest:        "file:" + path.Join(baseDir, "service_move_archive_03.tar") + "/tar://localhost/folder2/asset2.txt",
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := New()
+		for _, archive := range useCase.archives {
+			_ = service.Delete(ctx, archive)
+		}
+		err := service.Upload(ctx, useCase.asset, 0644, strings.NewReader("test 1"))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		err = service.Move(ctx, useCase.source, useCase.dest)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		exists, _ := service.Exists(ctx, useCase.asset)
+		assert.False(t, exists, useCase.description)
+		exists, _ = service.Exists(ctx, useCase.archives[len(useCase.archives)-1])
+		assert.True(t, exists, useCase.description)
+		for URL, expect := range useCase.expect {
+			data, err := service.DownloadWithURL(ctx, URL)
+			assert.Nil(t, err, useCase.description)
+			assert.EqualValues(t, expect, string(data), useCase.description)
+		}
+		for _, archive := range useCase.archives {
+			_ = service.Delete(ctx, archive)
+		}
+		_ = service.CloseAll()
+	}
+}
@@ -0,0 +1,14 @@
+package option
+
+import "github.com/viant/afs/storage"
+
+//ArchiveIndex represents archive index option, the index maps archive entries to content offsets for random access Open and walk free List
+type ArchiveIndex struct {
+	URL     string          //index persistence URL, index is kept in memory only if empty
+	Manager storage.Manager //index persistence manager, archive manager is used if empty
+}
+
+//NewArchiveIndex returns an archive index option
+func NewArchiveIndex(URL string, manager storage.Manager) *ArchiveIndex {
+	return &ArchiveIndex{URL: URL, Manager: manager}
+}
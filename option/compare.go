@@ -0,0 +1,17 @@
+package option
+
+import "time"
+
+//Compare represents entries comparison, modification times are equal if they do not differ more than Tolerance
+type Compare struct {
+	Size      bool
+	ModTime   bool
+	Tolerance time.Duration
+	Checksum  bool //compares content MD5
+	Content   bool //compares content byte by byte
+}
+
+//NewCompare returns a compare option
+func NewCompare(size, modTime bool, tolerance time.Duration, checksum, content bool) *Compare {
+	return &Compare{Size: size, ModTime: modTime, Tolerance: tolerance, Checksum: checksum, Content: content}
+}
@@ -0,0 +1,13 @@
+package option
+
+//Compression represents compression codec option, it takes precedence over codec inferred from URL extension
+type Compression struct {
+	Codec string //codec name, i.e. gzip, zstd or xz
+	//ListSize enables reading encoded content trailer to list uncompressed size with delegates other than file, each listed file is opened
+	ListSize bool
+}
+
+//NewCompression returns a compression option
+func NewCompression(codec string) *Compression {
+	return &Compression{Codec: codec}
+}
@@ -0,0 +1,12 @@
+package option
+
+//Continuation represents listing continuation option
+type Continuation struct {
+	//Token represents opaque token returned by storage.Iterator
+	Token string
+}
+
+//NewContinuation creates a continuation option
+func NewContinuation(token string) *Continuation {
+	return &Continuation{Token: token}
+}
@@ -0,0 +1,15 @@
+package option
+
+//Delta represents delta transfer option, existing destination file is updated with source blocks missing in destination copy if destination manager implements delta.Patcher,
+//only file manager implements delta.Patcher, remote destinations (i.e. scp) receive a plain upload,
+//Matched and Literal are incremented with bytes reused from destination copy and bytes transferred from source
+type Delta struct {
+	BlockSize int
+	Matched   int64
+	Literal   int64
+}
+
+//NewDelta returns a delta transfer option, zero block size uses default block size
+func NewDelta(blockSize int) *Delta {
+	return &Delta{BlockSize: blockSize}
+}
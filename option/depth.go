@@ -0,0 +1,16 @@
+package option
+
+//Depth represents walk depth limit, 1 visits only location entries, zero means no limit
+type Depth struct {
+	Max int
+}
+
+//NewDepth returns a depth option
+func NewDepth(max int) *Depth {
+	return &Depth{Max: max}
+}
+
+//Allows returns true if entries below folder at supplied depth can be visited
+func (d *Depth) Allows(depth int) bool {
+	return d == nil || d.Max <= 0 || depth < d.Max
+}
@@ -0,0 +1,12 @@
+package option
+
+//Manifest represents checksum manifest option
+type Manifest struct {
+	Format    string //sum (sha256sum compatible) or json, inferred from manifest URL extension if not set
+	Algorithm string //checksum algorithm, sha256 if not set
+}
+
+//NewManifest returns a manifest option
+func NewManifest(format, algorithm string) *Manifest {
+	return &Manifest{Format: format, Algorithm: algorithm}
+}
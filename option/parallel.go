@@ -0,0 +1,14 @@
+package option
+
+//Parallel represents parallel walk option, Workers bounds concurrent listing and opening,
+//handler is invoked concurrently only if ConcurrentHandler is set, otherwise handler calls are serialized,
+//an error or handler returning false for a file stops the walk, entries already in progress are still visited
+type Parallel struct {
+	Workers           int
+	ConcurrentHandler bool
+}
+
+//NewParallel returns a parallel walk option
+func NewParallel(workers int, concurrentHandler bool) *Parallel {
+	return &Parallel{Workers: workers, ConcurrentHandler: concurrentHandler}
+}
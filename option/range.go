@@ -0,0 +1,16 @@
+package option
+
+//Range represents content byte range option
+type Range struct {
+	Offset int64
+	//Length represents range length, zero means till the end
+	Length int64
+}
+
+//NewRange creates a range option
+func NewRange(offset, length int64) *Range {
+	return &Range{
+		Offset: offset,
+		Length: length,
+	}
+}
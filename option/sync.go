@@ -0,0 +1,28 @@
+package option
+
+import "time"
+
+//Sync represents sync option
+type Sync struct {
+	Checksum  bool          //compares content checksum instead of size and modification time
+	Delete    bool          //deletes destination entries missing in source
+	DryRun    bool          //reports changes without modifying destination
+	Tolerance time.Duration //source modification time tolerance, one second if not set
+}
+
+//NewSync returns a sync option
+func NewSync(checksum, delete, dryRun bool) *Sync {
+	return &Sync{Checksum: checksum, Delete: delete, DryRun: dryRun}
+}
+
+//Changes represents relative paths of synchronized entries, supplied as Sync option it is populated with planned (dry run) or applied changes
+type Changes struct {
+	Added   []string
+	Updated []string
+	Deleted []string
+}
+
+//NewChanges returns a sync changes option
+func NewChanges() *Changes {
+	return &Changes{}
+}
@@ -0,0 +1,31 @@
+package option
+
+import "time"
+
+//Transfer represents copy transfer statistics, supplied as Copy option it is populated with transfer summary
+type Transfer struct {
+	Files      int
+	Bytes      int64
+	TotalFiles int   //-1 if unknown
+	TotalBytes int64 //-1 if unknown
+	Current    string
+	Rate       float64 //bytes per second
+	Elapsed    time.Duration
+}
+
+//NewTransfer returns a transfer summary option
+func NewTransfer() *Transfer {
+	return &Transfer{TotalFiles: -1, TotalBytes: -1}
+}
+
+//Progress represents copy progress option, Handler is called after each transferred file (calls are serialized),
+//Total enables source listing before transfer to report total files and bytes
+type Progress struct {
+	Handler func(transfer *Transfer)
+	Total   bool
+}
+
+//NewProgress returns a progress option
+func NewProgress(handler func(transfer *Transfer), total bool) *Progress {
+	return &Progress{Handler: handler, Total: total}
+}
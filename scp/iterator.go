@@ -0,0 +1,52 @@
+package scp
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"os"
+	"path"
+)
+
+//ListIter returns an iterator streaming remote entries as they are transferred, continuation token resumes after the returned entries count
+func (m *manager) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	token, err := iterator.Continuation(options)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, URLPath := url.Base(URL, Scheme)
+	srv, err := m.Storager(ctx, baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := srv.(*storager)
+	if !ok {
+		return nil, fmt.Errorf("unsupported storager type: expected: %T, but had %T", service, srv)
+	}
+	match, _ := option.GetListOptions(options)
+	return iterator.NewStream(ctx, func(ctx context.Context, emit func(object storage.Object) bool) error {
+		isFirst := true
+		toContinue := true
+		return service.walk(ctx, URLPath, false, func(relative string, info os.FileInfo, reader io.Reader) (bool, error) {
+			if isFirst {
+				isFirst = false
+				if info.IsDir() {
+					//skip listed directory
+					return true, nil
+				}
+				toContinue = emit(object.New(URL, info, nil))
+				return toContinue, nil
+			}
+			if !toContinue || !match(relative, info) {
+				return toContinue, nil
+			}
+			toContinue = emit(object.New(url.Join(baseURL, path.Join(URLPath, info.Name())), info, nil))
+			return toContinue, nil
+		})
+	}, token), nil
+}
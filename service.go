@@ -3,6 +3,7 @@ package afs
 import (
 	"context"
 	"fmt"
+	"github.com/viant/afs/diff"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/mem"
 	"github.com/viant/afs/option"
@@ -19,6 +20,7 @@ import (
 //Service represents storage storage
 type Service interface {
 	storage.Lister
+	storage.IterLister
 	storage.Opener
 	storage.Uploader
 	storage.BatchUploader
@@ -38,6 +40,15 @@ type Service interface {
 	storage.Copier
 	storage.Mover
 
+	//Checksum returns content checksum for supplied algorithm (checksum.MD5, checksum.SHA256, checksum.CRC32C)
+	Checksum(ctx context.Context, URL string, algorithm string, options ...storage.Option) ([]byte, error)
+
+	//Diff returns entries added, removed or modified between left and right locations
+	Diff(ctx context.Context, leftURL, rightURL string, options ...storage.Option) (diff.Changes, error)
+
+	//Sync transfers only added or modified source entries to destination, optionally deleting extraneous destination entries
+	Sync(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error
+
 	//Initialises manager for baseURL with storage options (i.e. auth)
 	Init(ctx context.Context, baseURL string, options ...storage.Option) error
 
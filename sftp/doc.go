@@ -0,0 +1,2 @@
+//Package sftp implements SSH File Transfer Protocol storager and abstract file manager
+package sftp
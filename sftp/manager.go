@@ -0,0 +1,128 @@
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/base"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/scp"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"net/http"
+	"os"
+)
+
+const defaultTimeoutMs = 15000
+
+type manager struct {
+	*base.Manager
+}
+
+//Move moves source to destination, cross host move falls back to copy and delete
+func (m *manager) Move(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error {
+	sourceBaseURL, sourcePath := url.Base(sourceURL, Scheme)
+	destBaseURL, destPath := url.Base(destURL, Scheme)
+	source, err := m.storager(ctx, sourceBaseURL, options)
+	if err != nil {
+		return err
+	}
+	if sourceBaseURL == destBaseURL {
+		return source.Move(ctx, sourcePath, destPath, options...)
+	}
+	info, err := source.Get(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("unsupported cross host directory move: %v -> %v", sourceURL, destURL)
+	}
+	reader, err := source.Open(ctx, sourcePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+	if err = m.Upload(ctx, destURL, info.Mode(), reader, options...); err != nil {
+		return err
+	}
+	return source.Delete(ctx, sourcePath)
+}
+
+//NewWriter returns a writer for supplied URL
+func (m *manager) NewWriter(ctx context.Context, URL string, mode os.FileMode, options ...storage.Option) (io.WriteCloser, error) {
+	baseURL, URLPath := url.Base(URL, Scheme)
+	srv, err := m.storager(ctx, baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	return srv.NewWriter(ctx, URLPath, mode, options...)
+}
+
+//ErrorCode returns an error code
+func (m *manager) ErrorCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	cause := errors.Cause(err)
+	switch {
+	case os.IsNotExist(cause):
+		return http.StatusNotFound
+	case os.IsPermission(cause):
+		return http.StatusForbidden
+	}
+	return 0
+}
+
+func (m *manager) storager(ctx context.Context, baseURL string, options []storage.Option) (*storager, error) {
+	srv, err := m.Storager(ctx, baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := srv.(*storager)
+	if !ok {
+		return nil, fmt.Errorf("unsupported storager type: expected: %T, but had %T", service, srv)
+	}
+	return service, nil
+}
+
+func (m *manager) provider(ctx context.Context, baseURL string, options ...storage.Option) (storage.Storager, error) {
+	options = m.Options(options)
+	timeout := option.Timeout{}
+	host := url.Host(baseURL)
+	clientConfig := &ssh.ClientConfig{}
+	if _, ok := option.Assign(options, &clientConfig, &timeout); ok && clientConfig != nil && clientConfig.User != "" {
+		return NewStorager(host, timeout.Duration, clientConfig)
+	}
+	var basicAuth option.BasicAuth
+	var keyAuth scp.KeyAuth
+	var authProvider scp.AuthProvider
+	option.Assign(options, &basicAuth, &keyAuth, &authProvider, &timeout)
+	if timeout.Duration == 0 {
+		timeout = option.NewTimeout(defaultTimeoutMs)
+	}
+	if basicAuth == nil && keyAuth == nil && authProvider == nil {
+		keyAuth, _ = scp.LocalhostKeyAuth("")
+	}
+	if authProvider == nil {
+		authProvider = scp.NewAuthProvider(keyAuth, basicAuth)
+	}
+	config, err := authProvider.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create ssh config")
+	}
+	return NewStorager(host, timeout.Duration, config)
+}
+
+func newManager(options ...storage.Option) *manager {
+	result := &manager{}
+	baseMgr := base.New(result, Scheme, result.provider, options)
+	result.Manager = baseMgr
+	return result
+}
+
+//New creates sftp manager
+func New(options ...storage.Option) storage.Manager {
+	return newManager(options...)
+}
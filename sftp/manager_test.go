@@ -0,0 +1,96 @@
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestManager(t *testing.T) {
+	address := startServer(t)
+	ctx := context.Background()
+	baseURL := fmt.Sprintf("sftp://%v%v", address, t.TempDir())
+	auth := option.NewBasicAuth(testUser, testPassword)
+
+	var useCases = []struct {
+		description string
+		URL         string
+		content     string
+	}{
+		{
+			description: "root asset",
+			URL:         url.Join(baseURL, "asset1.txt"),
+			content:     "abc",
+		},
+		{
+			description: "nested asset",
+			URL:         url.Join(baseURL, "folder1/sub/asset2.txt"),
+			content:     "xyz",
+		},
+	}
+
+	mgr := newManager(auth)
+	defer mgr.Close()
+	assert.EqualValues(t, Scheme, mgr.Scheme())
+	for _, useCase := range useCases {
+		err := mgr.Upload(ctx, useCase.URL, 0644, strings.NewReader(useCase.content))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		has, err := mgr.Exists(ctx, useCase.URL)
+		assert.Nil(t, err, useCase.description)
+		assert.True(t, has, useCase.description)
+
+		object, err := mgr.Object(ctx, useCase.URL)
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, useCase.URL, object.URL(), useCase.description)
+		}
+		reader, err := mgr.OpenURL(ctx, useCase.URL)
+		if assert.Nil(t, err, useCase.description) {
+			data, _ := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.EqualValues(t, useCase.content, string(data), useCase.description)
+		}
+
+		writer, err := mgr.NewWriter(ctx, useCase.URL+".w", 0644)
+		if assert.Nil(t, err, useCase.description) {
+			_, err = writer.Write([]byte(useCase.content + useCase.content))
+			assert.Nil(t, err, useCase.description)
+			assert.Nil(t, writer.Close(), useCase.description)
+			object, err := mgr.Object(ctx, useCase.URL+".w")
+			if assert.Nil(t, err, useCase.description) {
+				assert.EqualValues(t, 2*len(useCase.content), object.Size(), useCase.description)
+			}
+		}
+
+		var mover storage.Mover = mgr
+		err = mover.Move(ctx, useCase.URL+".w", useCase.URL+".m")
+		assert.Nil(t, err, useCase.description)
+		has, _ = mgr.Exists(ctx, useCase.URL+".w")
+		assert.False(t, has, useCase.description)
+
+		_, err = mgr.OpenURL(ctx, useCase.URL+".w")
+		assert.EqualValues(t, http.StatusNotFound, mgr.ErrorCode(err), useCase.description)
+	}
+
+	objects, err := mgr.List(ctx, baseURL)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 4, len(objects))
+	}
+	err = mgr.Create(ctx, url.Join(baseURL, "folder2"), os.ModeDir|0755, true)
+	assert.Nil(t, err)
+	err = mgr.Delete(ctx, url.Join(baseURL, "folder1"))
+	assert.Nil(t, err)
+	objects, err = mgr.List(ctx, baseURL)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 4, len(objects))
+	}
+}
@@ -0,0 +1,10 @@
+package sftp
+
+import (
+	"github.com/viant/afs/storage"
+)
+
+//Provider returns a sftp manager
+func Provider(options ...storage.Option) (storage.Manager, error) {
+	return New(options...), nil
+}
@@ -0,0 +1,48 @@
+package sftp
+
+import (
+	"github.com/pkg/sftp"
+	"github.com/viant/afs/base"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io"
+)
+
+//reader represents random access sftp file reader
+type reader struct {
+	io.Reader
+	handle *sftp.File
+	size   int64
+}
+
+//ReadAt reads len(dest) bytes from the file starting at byte offset off
+func (r *reader) ReadAt(dest []byte, off int64) (int, error) {
+	return r.handle.ReadAt(dest, off)
+}
+
+//Seek sets the offset for the next Read
+func (r *reader) Seek(offset int64, whence int) (int64, error) {
+	return r.handle.Seek(offset, whence)
+}
+
+//Size returns file size
+func (r *reader) Size() int64 {
+	return r.size
+}
+
+//Close closes underlying file handle
+func (r *reader) Close() error {
+	return r.handle.Close()
+}
+
+func newReader(handle *sftp.File, size int64, options []storage.Option) *reader {
+	result := &reader{Reader: handle, handle: handle, size: size}
+	stream := &option.Stream{}
+	if _, ok := option.Assign(options, &stream); ok && stream.PartSize > 0 {
+		if stream.Size == 0 {
+			stream.Size = int(size)
+		}
+		result.Reader = base.NewStreamReader(stream, handle)
+	}
+	return result
+}
@@ -0,0 +1,4 @@
+package sftp
+
+//Scheme defines sftp URL scheme
+const Scheme = "sftp"
@@ -0,0 +1,300 @@
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/pkg/sftp"
+	"github.com/viant/afs/base"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/scp"
+	"github.com/viant/afs/storage"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+//posixRenameExtension represents openssh rename extension replacing existing destination
+const posixRenameExtension = "posix-rename@openssh.com"
+
+type storager struct {
+	base.Storager
+	address string
+	*ssh.ClientConfig
+	sshClient *ssh.Client
+	client    *sftp.Client
+	timeout   time.Duration
+}
+
+func (s *storager) connect() (err error) {
+	if s.ClientConfig.Timeout == 0 {
+		s.ClientConfig.Timeout = s.timeout
+	}
+	if s.sshClient, err = ssh.Dial("tcp", s.address, s.ClientConfig); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to dial %s", s.address))
+	}
+	if s.client, err = sftp.NewClient(s.sshClient); err != nil {
+		_ = s.sshClient.Close()
+		return errors.Wrap(err, fmt.Sprintf("failed to start sftp subsystem %s", s.address))
+	}
+	return nil
+}
+
+//Exists returns true if location exists
+func (s *storager) Exists(ctx context.Context, location string, options ...storage.Option) (bool, error) {
+	_, err := s.client.Stat(location)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+//Get returns a file info for supplied location
+func (s *storager) Get(ctx context.Context, location string, options ...storage.Option) (os.FileInfo, error) {
+	info, err := s.client.Stat(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to stat %v", location)
+	}
+	return s.namedInfo(location, info), nil
+}
+
+//List lists location assets
+func (s *storager) List(ctx context.Context, location string, options ...storage.Option) ([]os.FileInfo, error) {
+	stat, err := s.Get(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.IsDir() {
+		return []os.FileInfo{stat}, nil
+	}
+	files, err := s.client.ReadDir(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read dir %v", location)
+	}
+	match, page := option.GetListOptions(options)
+	var result = make([]os.FileInfo, 0, len(files)+1)
+	result = append(result, stat)
+	for _, info := range files {
+		if !match(location, info) {
+			continue
+		}
+		page.Increment()
+		if page.ShallSkip() {
+			continue
+		}
+		if info.Mode()&os.ModeSymlink > 0 {
+			if linkname, err := s.client.ReadLink(path.Join(location, info.Name())); err == nil {
+				info = file.NewInfo(info.Name(), info.Size(), info.Mode(), info.ModTime(), info.IsDir(), object.NewLink(linkname, "", info))
+			}
+		}
+		result = append(result, info)
+		if page.HasReachedLimit() {
+			break
+		}
+	}
+	return result, nil
+}
+
+//Open returns a random access reader for supplied location, option.Stream enables chunked reading
+func (s *storager) Open(ctx context.Context, location string, options ...storage.Option) (io.ReadCloser, error) {
+	handle, err := s.client.Open(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open %v", location)
+	}
+	info, err := handle.Stat()
+	if err != nil {
+		_ = handle.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		_ = handle.Close()
+		return nil, fmt.Errorf("%v: is directory", location)
+	}
+	return newReader(handle, info.Size(), options), nil
+}
+
+//Upload uploads content for supplied destination
+func (s *storager) Upload(ctx context.Context, destination string, mode os.FileMode, reader io.Reader, options ...storage.Option) error {
+	writer, err := s.NewWriter(ctx, destination, mode)
+	if err != nil {
+		return err
+	}
+	if reader != nil {
+		if _, err = io.Copy(writer, reader); err != nil {
+			_ = writer.Close()
+			return errors.Wrapf(err, "failed to upload %v", destination)
+		}
+	}
+	return writer.Close()
+}
+
+//NewWriter returns a writer for supplied destination, by default existing content is truncated unless option.OsFlag is used
+func (s *storager) NewWriter(ctx context.Context, destination string, mode os.FileMode, options ...storage.Option) (io.WriteCloser, error) {
+	flagOpt := option.OsFlag(0)
+	option.Assign(options, &flagOpt)
+	flag := os.O_WRONLY | os.O_CREATE
+	if flagOpt > 0 {
+		flag |= int(flagOpt)
+	} else {
+		flag |= os.O_TRUNC
+	}
+	parent, _ := path.Split(destination)
+	if parent != "" {
+		if err := s.client.MkdirAll(parent); err != nil {
+			return nil, errors.Wrapf(err, "unable to create parent for %v", destination)
+		}
+	}
+	handle, err := s.client.OpenFile(destination, flag)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open file: %v", destination)
+	}
+	if mode != 0 {
+		if err = handle.Chmod(mode.Perm()); err != nil {
+			_ = handle.Close()
+			return nil, err
+		}
+	}
+	return handle, nil
+}
+
+//Create creates a file or directory
+func (s *storager) Create(ctx context.Context, destination string, mode os.FileMode, reader io.Reader, isDir bool, options ...storage.Option) error {
+	if !isDir {
+		if reader == nil {
+			reader = strings.NewReader("")
+		}
+		return s.Upload(ctx, destination, mode, reader)
+	}
+	if info, err := s.client.Stat(destination); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%v: is file", destination)
+		}
+		return nil
+	}
+	if err := s.client.MkdirAll(destination); err != nil {
+		return errors.Wrapf(err, "unable to create dir %v", destination)
+	}
+	if mode.Perm() == 0 {
+		return nil
+	}
+	return s.client.Chmod(destination, mode.Perm())
+}
+
+//Delete removes supplied file or directory
+func (s *storager) Delete(ctx context.Context, location string, options ...storage.Option) error {
+	info, err := s.client.Lstat(location)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return s.client.Remove(location)
+	}
+	return s.client.RemoveAll(location)
+}
+
+//Move moves source to destination, existing destination file is replaced atomically if server supports posix-rename@openssh.com
+func (s *storager) Move(ctx context.Context, source, destination string, options ...storage.Option) error {
+	parent, _ := path.Split(destination)
+	if parent != "" {
+		if err := s.client.MkdirAll(parent); err != nil {
+			return errors.Wrapf(err, "unable to create parent for %v", destination)
+		}
+	}
+	info, err := s.client.Lstat(destination)
+	if err != nil {
+		return s.client.Rename(source, destination)
+	}
+	if _, ok := s.client.HasExtension(posixRenameExtension); ok && !info.IsDir() {
+		return s.client.PosixRename(source, destination)
+	}
+	if err = s.Delete(ctx, destination); err != nil {
+		return errors.Wrapf(err, "unable to remove %v", destination)
+	}
+	return s.client.Rename(source, destination)
+}
+
+//namedInfo ensures info carries the last location path element as its name
+func (s *storager) namedInfo(location string, info os.FileInfo) os.FileInfo {
+	_, name := path.Split(strings.TrimRight(location, "/"))
+	if name == "" || info.Name() == name {
+		return info
+	}
+	return file.NewInfo(name, info.Size(), info.Mode(), info.ModTime(), info.IsDir(), info.Sys())
+}
+
+//Close closes sftp and ssh clients
+func (s *storager) Close() error {
+	err := s.client.Close()
+	if e := s.sshClient.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+//FilterAuthOptions filters auth options
+func (s *storager) FilterAuthOptions(options []storage.Option) []storage.Option {
+	var authOptions = make([]storage.Option, 0)
+	var basicAuth option.BasicAuth
+	var keyAuth scp.KeyAuth
+	var authProvider scp.AuthProvider
+	option.Assign(options, &basicAuth, &keyAuth, &authProvider)
+	for _, candidate := range []storage.Option{basicAuth, keyAuth, authProvider} {
+		if candidate != nil {
+			authOptions = append(authOptions, candidate)
+		}
+	}
+	return authOptions
+}
+
+//IsAuthChanged return true if auth has changes
+func (s *storager) IsAuthChanged(authOptions []storage.Option) bool {
+	if len(authOptions) == 0 {
+		return false
+	}
+	sshConfig, _ := clientConfig(authOptions)
+	if sshConfig == nil {
+		return false
+	}
+	return sshConfig.User != s.ClientConfig.User
+}
+
+//clientConfig returns ssh client config for supplied auth options or nil
+func clientConfig(options []storage.Option) (*ssh.ClientConfig, error) {
+	var basicAuth option.BasicAuth
+	var keyAuth scp.KeyAuth
+	var authProvider scp.AuthProvider
+	option.Assign(options, &basicAuth, &keyAuth, &authProvider)
+	if basicAuth == nil && keyAuth == nil && authProvider == nil {
+		return nil, nil
+	}
+	if authProvider == nil {
+		authProvider = scp.NewAuthProvider(keyAuth, basicAuth)
+	}
+	return authProvider.ClientConfig()
+}
+
+//NewStorager returns a sftp storager
+func NewStorager(address string, timeout time.Duration, config *ssh.ClientConfig) (storage.Storager, error) {
+	return newStorager(address, timeout, config)
+}
+
+func newStorager(address string, timeout time.Duration, config *ssh.ClientConfig) (*storager, error) {
+	if !strings.Contains(address, ":") {
+		address += fmt.Sprintf(":%d", scp.DefaultPort)
+	}
+	result := &storager{
+		address:      address,
+		ClientConfig: config,
+		timeout:      timeout,
+	}
+	result.Storager.List = result.List
+	return result, result.connect()
+}
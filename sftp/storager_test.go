@@ -0,0 +1,121 @@
+package sftp
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/scp"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStorager(t *testing.T) {
+	address := startServer(t)
+	ctx := context.Background()
+	baseDir := t.TempDir()
+
+	config, err := scp.NewAuthProvider(nil, option.NewBasicAuth(testUser, testPassword)).ClientConfig()
+	if !assert.Nil(t, err) {
+		return
+	}
+	srv, err := newStorager(address, 5*time.Second, config)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer srv.Close()
+
+	var useCases = []struct {
+		description string
+		location    string
+		content     string
+		offset      int64
+		expectRange string
+		partSize    int
+	}{
+		{
+			description: "simple file",
+			location:    path.Join(baseDir, "s1", "asset.txt"),
+			content:     "test is test",
+			offset:      5,
+			expectRange: "is",
+		},
+		{
+			description: "streamed file",
+			location:    path.Join(baseDir, "s2", "sub", "asset.txt"),
+			content:     strings.Repeat("0123456789", 20),
+			offset:      10,
+			expectRange: "01",
+			partSize:    7,
+		},
+	}
+
+	for _, useCase := range useCases {
+		has, err := srv.Exists(ctx, useCase.location)
+		assert.Nil(t, err, useCase.description)
+		assert.False(t, has, useCase.description)
+
+		err = srv.Upload(ctx, useCase.location, 0644, strings.NewReader(useCase.content))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		has, err = srv.Exists(ctx, useCase.location)
+		assert.True(t, has, useCase.description)
+
+		info, err := srv.Get(ctx, useCase.location)
+		if assert.Nil(t, err, useCase.description) {
+			assert.EqualValues(t, len(useCase.content), info.Size(), useCase.description)
+			assert.EqualValues(t, path.Base(useCase.location), info.Name(), useCase.description)
+		}
+
+		var options = []storage.Option{}
+		if useCase.partSize > 0 {
+			options = append(options, option.NewStream(useCase.partSize, 0))
+		}
+		reader, err := srv.Open(ctx, useCase.location, options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.content, string(data), useCase.description)
+
+		readerAt, ok := reader.(io.ReaderAt)
+		if assert.True(t, ok, useCase.description) {
+			fragment := make([]byte, len(useCase.expectRange))
+			_, err = readerAt.ReadAt(fragment, useCase.offset)
+			assert.Nil(t, err, useCase.description)
+			assert.EqualValues(t, useCase.expectRange, string(fragment), useCase.description)
+		}
+		_ = reader.Close()
+
+		parent, _ := path.Split(useCase.location)
+		files, err := srv.List(ctx, parent)
+		if assert.Nil(t, err, useCase.description) {
+			assert.True(t, len(files) >= 2, useCase.description)
+			assert.True(t, files[0].IsDir(), useCase.description)
+		}
+
+		moved := useCase.location + ".moved"
+		err = srv.Upload(ctx, moved, 0644, strings.NewReader("existing"))
+		assert.Nil(t, err, useCase.description)
+		err = srv.Move(ctx, useCase.location, moved)
+		assert.Nil(t, err, useCase.description)
+		has, _ = srv.Exists(ctx, useCase.location)
+		assert.False(t, has, useCase.description)
+		if reader, err = srv.Open(ctx, moved); assert.Nil(t, err, useCase.description) {
+			data, _ = ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.EqualValues(t, useCase.content, string(data), useCase.description)
+		}
+
+		err = srv.Delete(ctx, parent)
+		assert.Nil(t, err, useCase.description)
+		has, _ = srv.Exists(ctx, moved)
+		assert.False(t, has, useCase.description)
+	}
+}
@@ -0,0 +1,8 @@
+package storage
+
+import "context"
+
+//Hasher represents a manager returning content checksum without reading content, empty checksum means it is not known
+type Hasher interface {
+	Checksum(ctx context.Context, URL string, algorithm string, options ...Option) ([]byte, error)
+}
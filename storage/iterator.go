@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+//Iterator represents object listing cursor
+type Iterator interface {
+	//Next advances cursor, it returns false when listing is exhausted or failed
+	Next() bool
+	//Object returns current object
+	Object() Object
+	//Err returns listing error if any
+	Err() error
+	//Token returns opaque continuation token resuming listing after current object
+	Token() string
+	io.Closer
+}
+
+//IterLister represents streaming lister
+type IterLister interface {
+	//ListIter returns iterator over supplied URL entries (excluding the listed directory itself), option.Continuation resumes previous listing
+	ListIter(ctx context.Context, URL string, options ...Option) (Iterator, error)
+}
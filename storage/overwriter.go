@@ -0,0 +1,11 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+//Overwriter represents a manager modifying stored content in place, overwrite reads stored content as base and writes changes at writer offset
+type Overwriter interface {
+	Overwrite(ctx context.Context, URL string, overwrite func(base io.ReaderAt, size int64, writer io.WriteSeeker) error, options ...Option) error
+}
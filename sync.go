@@ -0,0 +1,162 @@
+package afs
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/checksum"
+	"github.com/viant/afs/delta"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultModTimeTolerance = time.Second
+
+//Sync synchronizes destination with source, only added or modified entries are transferred
+func (s *service) Sync(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error {
+	sourceURL = url.Normalize(sourceURL, file.Scheme)
+	destURL = url.Normalize(destURL, file.Scheme)
+	sourceOptions := option.NewSource()
+	destOptions := option.NewDest()
+	syncOption := &option.Sync{}
+	var changes *option.Changes
+	var deltaOption *option.Delta
+	option.Assign(options, &sourceOptions, &destOptions, &syncOption, &changes, &deltaOption)
+	listOptions := matchOptions(options)
+	source, err := s.index(ctx, sourceURL, append(listOptions, *sourceOptions...))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list source: %v", sourceURL)
+	}
+	dest := map[string]storage.Object{}
+	if exists, _ := s.Exists(ctx, destURL, *destOptions...); exists {
+		if sourceFile, ok := source[""]; ok {
+			if destObject, err := s.Object(ctx, destURL, *destOptions...); err == nil && destObject.IsDir() {
+				destURL = url.Join(destURL, sourceFile.Name())
+			}
+		}
+	}
+	if exists, _ := s.Exists(ctx, destURL, *destOptions...); exists {
+		if dest, err = s.index(ctx, destURL, append(listOptions, *destOptions...)); err != nil {
+			return errors.Wrapf(err, "failed to list dest: %v", destURL)
+		}
+	}
+	if changes == nil {
+		changes = &option.Changes{}
+	}
+	*changes = option.Changes{}
+	for _, relative := range sortedKeys(source) {
+		sourceObject := source[relative]
+		destObject, ok := dest[relative]
+		if !ok {
+			changes.Added = append(changes.Added, relative)
+			continue
+		}
+		if sourceObject.IsDir() && destObject.IsDir() {
+			continue
+		}
+		modified, err := s.isModified(ctx, sourceObject, destObject, syncOption, *sourceOptions, *destOptions)
+		if err != nil {
+			return err
+		}
+		if modified {
+			changes.Updated = append(changes.Updated, relative)
+		}
+	}
+	if syncOption.Delete {
+		for _, relative := range sortedKeys(dest) {
+			if _, ok := source[relative]; ok || hasParent(changes.Deleted, relative) {
+				continue
+			}
+			changes.Deleted = append(changes.Deleted, relative)
+		}
+	}
+	if syncOption.DryRun {
+		return nil
+	}
+	for _, relative := range changes.Updated {
+		if source[relative].IsDir() == dest[relative].IsDir() {
+			continue
+		}
+		//type changed
+		if err = s.Delete(ctx, joinURL(destURL, relative), *destOptions...); err != nil {
+			return err
+		}
+	}
+	var patcher delta.Patcher
+	if deltaOption != nil {
+		if patcher, err = s.deltaPatcher(ctx, destURL, *destOptions); err != nil {
+			return err
+		}
+	}
+	updates := append(append([]string{}, changes.Added...), changes.Updated...)
+	sort.Strings(updates)
+	for _, relative := range updates {
+		var update delta.Patcher
+		if destObject, ok := dest[relative]; ok && !destObject.IsDir() && !source[relative].IsDir() {
+			update = patcher
+		}
+		if err = s.transfer(ctx, source[relative], joinURL(destURL, relative), update, deltaOption, *sourceOptions, *destOptions); err != nil {
+			return err
+		}
+	}
+	for _, relative := range changes.Deleted {
+		if err = s.Delete(ctx, joinURL(destURL, relative), *destOptions...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//isModified returns true if entry type or size differs, source is newer (beyond tolerance) or checksum differs
+func (s *service) isModified(ctx context.Context, source, dest storage.Object, syncOption *option.Sync, sourceOptions, destOptions []storage.Option) (bool, error) {
+	if source.IsDir() != dest.IsDir() || source.Size() != dest.Size() {
+		return true, nil
+	}
+	if !syncOption.Checksum {
+		tolerance := syncOption.Tolerance
+		if tolerance == 0 {
+			tolerance = defaultModTimeTolerance
+		}
+		return source.ModTime().Sub(dest.ModTime()) > tolerance, nil
+	}
+	sourceHash, err := s.Checksum(ctx, source.URL(), checksum.MD5, sourceOptions...)
+	if err != nil {
+		return false, err
+	}
+	destHash, err := s.Checksum(ctx, dest.URL(), checksum.MD5, destOptions...)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(sourceHash, destHash), nil
+}
+
+//transfer creates destination folder or uploads source file content, existing destination file is updated with delta if patcher is set
+func (s *service) transfer(ctx context.Context, source storage.Object, destURL string, patcher delta.Patcher, deltaOption *option.Delta, sourceOptions, destOptions []storage.Option) error {
+	if source.IsDir() {
+		return s.Create(ctx, destURL, source.Mode()|os.ModeDir, true, destOptions...)
+	}
+	reader, err := s.Open(ctx, source, sourceOptions...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = reader.Close() }()
+	if patcher != nil {
+		return s.deltaUpload(ctx, reader, patcher, destURL, deltaOption, destOptions)
+	}
+	return s.Upload(ctx, destURL, source.Mode(), reader, destOptions...)
+}
+
+func hasParent(candidates []string, relative string) bool {
+	for _, candidate := range candidates {
+		if strings.HasPrefix(relative, candidate+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package afs
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/matcher"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_Sync(t *testing.T) {
+	ctx := context.Background()
+	baseDir := os.TempDir()
+	_, filename, _, _ := runtime.Caller(0)
+	testDir, _ := path.Split(filename)
+	ignore, _ := matcher.NewIgnore([]string{"*.log"})
+	past := time.Now().Add(-time.Hour)
+
+	var useCases = []struct {
+		description   string
+		sourceURL     string
+		destURL       string
+		source        map[string]string
+		dest          map[string]string
+		destModTime   *time.Time
+		options       []storage.Option
+		expect        option.Changes
+		expectContent map[string]string
+	}{
+		{
+			description: "initial sync",
+			sourceURL:   "mem://localhost/sync/src01",
+			destURL:     path.Join(baseDir, "afs_sync_dst01"),
+			source:      map[string]string{"a.txt": "abc", "d/b.txt": "xyz"},
+			expect:      option.Changes{Added: []string{"a.txt", "d", "d/b.txt"}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "d/b.txt": "xyz",
+			},
+		},
+		{
+			description: "incremental sync",
+			sourceURL:   path.Join(baseDir, "afs_sync_src02"),
+			destURL:     "mem://localhost/sync/dst02",
+			source:      map[string]string{"a.txt": "abc", "b.txt": "new content", "d/c.txt": "xyz"},
+			dest:        map[string]string{"a.txt": "abc", "b.txt": "old", "x.txt": "extra"},
+			expect:      option.Changes{Added: []string{"d", "d/c.txt"}, Updated: []string{"b.txt"}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "b.txt": "new content", "d/c.txt": "xyz", "x.txt": "extra",
+			},
+		},
+		{
+			description: "sync with delete",
+			sourceURL:   "mem://localhost/sync/src03",
+			destURL:     "mem://localhost/sync/dst03",
+			source:      map[string]string{"a.txt": "abc"},
+			dest:        map[string]string{"a.txt": "abc", "x.txt": "extra", "y/z.txt": "extra"},
+			options:     []storage.Option{option.NewSync(false, true, false)},
+			expect:      option.Changes{Deleted: []string{"x.txt", "y"}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "x.txt": "", "y/z.txt": "",
+			},
+		},
+		{
+			description: "dry run",
+			sourceURL:   "mem://localhost/sync/src04",
+			destURL:     "mem://localhost/sync/dst04",
+			source:      map[string]string{"a.txt": "abc", "b.txt": "xyz"},
+			dest:        map[string]string{"x.txt": "extra"},
+			options:     []storage.Option{option.NewSync(false, true, true)},
+			expect:      option.Changes{Added: []string{"a.txt", "b.txt"}, Deleted: []string{"x.txt"}},
+			expectContent: map[string]string{
+				"a.txt": "", "x.txt": "extra",
+			},
+		},
+		{
+			description: "sync with ignore matcher",
+			sourceURL:   "mem://localhost/sync/src05",
+			destURL:     "mem://localhost/sync/dst05",
+			source:      map[string]string{"a.txt": "abc", "debug.log": "log"},
+			dest:        map[string]string{"app.log": "keep"},
+			options:     []storage.Option{option.NewSync(false, true, false), ignore},
+			expect:      option.Changes{Added: []string{"a.txt"}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "debug.log": "", "app.log": "keep",
+			},
+		},
+		{
+			description: "sync with checksum",
+			sourceURL:   "mem://localhost/sync/src06",
+			destURL:     "mem://localhost/sync/dst06",
+			source:      map[string]string{"a.txt": "abc", "b.txt": "xyz"},
+			dest:        map[string]string{"a.txt": "abc", "b.txt": "123"},
+			destModTime: &past,
+			options:     []storage.Option{option.NewSync(true, false, false)},
+			expect:      option.Changes{Updated: []string{"b.txt"}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "b.txt": "xyz",
+			},
+		},
+		{
+			description: "sync from zip",
+			sourceURL:   fmt.Sprintf("file:%v/zip/test/app.war/zip://localhost/WEB-INF/classes", testDir),
+			destURL:     "mem://localhost/sync/dst07",
+			dest:        map[string]string{"config.properties": "changed"},
+			expect:      option.Changes{Added: []string{"HelloWorld.class"}, Updated: []string{"config.properties"}},
+		},
+		{
+			description: "file sync",
+			sourceURL:   "mem://localhost/sync/src08/a.txt",
+			destURL:     "mem://localhost/sync/dst08",
+			source:      map[string]string{"": "abc"},
+			dest:        map[string]string{"b.txt": "xyz"},
+			expect:      option.Changes{Added: []string{""}},
+			expectContent: map[string]string{
+				"a.txt": "abc", "b.txt": "xyz",
+			},
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := New()
+		_ = service.Delete(ctx, useCase.destURL)
+		if !assert.Nil(t, upload(ctx, service, useCase.sourceURL, useCase.source, nil), useCase.description) {
+			continue
+		}
+		if !assert.Nil(t, upload(ctx, service, useCase.destURL, useCase.dest, useCase.destModTime), useCase.description) {
+			continue
+		}
+		changes := option.NewChanges()
+		err := service.Sync(ctx, useCase.sourceURL, useCase.destURL, append(useCase.options, changes)...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, *changes, useCase.description)
+		for name, expect := range useCase.expectContent {
+			data, _ := service.DownloadWithURL(ctx, joinURL(useCase.destURL, name))
+			assert.EqualValues(t, expect, string(data), useCase.description+" "+name)
+		}
+		syncOption := &option.Sync{}
+		if option.Assign(useCase.options, &syncOption); !syncOption.DryRun {
+			changes = option.NewChanges()
+			err = service.Sync(ctx, useCase.sourceURL, useCase.destURL, append(useCase.options, changes)...)
+			assert.Nil(t, err, useCase.description)
+			assert.EqualValues(t, option.Changes{}, *changes, useCase.description+" resync")
+		}
+		if !strings.Contains(useCase.sourceURL, "zip:") {
+			_ = service.Delete(ctx, useCase.sourceURL)
+		}
+		_ = service.Delete(ctx, useCase.destURL)
+	}
+}
+
+func upload(ctx context.Context, service Service, baseURL string, assets map[string]string, modTime *time.Time) error {
+	for name, content := range assets {
+		var options []storage.Option
+		if modTime != nil {
+			options = append(options, *modTime)
+		}
+		if err := service.Upload(ctx, joinURL(baseURL, name), file.DefaultFileOsMode, strings.NewReader(content), options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package tar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/storage"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+//Entry represents indexed archive entry as visited by walker
+type Entry struct {
+	Parent   string
+	Name     string
+	Offset   int64 //content offset, -1 if content can not be read directly (directory, symlink)
+	Size     int64
+	Mode     os.FileMode
+	ModTime  time.Time
+	IsDir    bool
+	Linkname string `json:",omitempty"`
+	LinkURL  string `json:",omitempty"`
+}
+
+//Info returns entry file info
+func (e *Entry) Info() os.FileInfo {
+	if e.Linkname != "" {
+		return file.NewInfo(e.Name, e.Size, e.Mode, e.ModTime, e.IsDir, object.NewLink(e.Linkname, e.LinkURL, nil))
+	}
+	return file.NewInfo(e.Name, e.Size, e.Mode, e.ModTime, e.IsDir)
+}
+
+//Index represents archive index, archive size and modification time are used to detect stale index
+type Index struct {
+	Size       int64
+	ModTime    time.Time
+	Compressed bool
+	Entries    []*Entry
+	locations  map[string]*Entry
+}
+
+//Lookup returns the first entry matching supplied location
+func (i *Index) Lookup(location string) *Entry {
+	if i.locations == nil {
+		i.locations = make(map[string]*Entry, len(i.Entries))
+		for _, entry := range i.Entries {
+			location := strings.Trim(path.Join(entry.Parent, entry.Name), "/")
+			if _, ok := i.locations[location]; !ok {
+				i.locations[location] = entry
+			}
+		}
+	}
+	return i.locations[strings.Trim(location, "/")]
+}
+
+//IsValid returns true if index matches archive size and modification time
+func (i *Index) IsValid(size int64, modTime time.Time) bool {
+	return i.Size == size && i.ModTime.Equal(modTime)
+}
+
+func newEntry(parent string, info os.FileInfo, offset int64) *Entry {
+	result := &Entry{Parent: parent, Name: info.Name(), Offset: -1, Size: info.Size(), Mode: info.Mode(), ModTime: info.ModTime(), IsDir: info.IsDir()}
+	if fileInfo, ok := info.(*file.Info); ok && fileInfo.Link != nil && fileInfo.Linkname != "" {
+		result.Linkname = fileInfo.Linkname
+		result.LinkURL = fileInfo.LinkURL
+		return result
+	}
+	if info.Mode().IsRegular() {
+		result.Offset = offset
+	}
+	return result
+}
+
+//loadIndex returns archive index if index option was supplied, cached or persisted index is rebuilt when stale
+func (s *storager) loadIndex(ctx context.Context) (*Index, error) {
+	if s.indexOption == nil {
+		return nil, nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	objects, err := s.manager.List(ctx, s.URL)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != 1 {
+		return nil, errors.Errorf("%v: not found", s.URL)
+	}
+	size, modTime := objects[0].Size(), objects[0].ModTime()
+	if s.index != nil && s.index.IsValid(size, modTime) {
+		return s.index, nil
+	}
+	if index, err := s.readIndex(ctx); err == nil && index.IsValid(size, modTime) {
+		s.index = index
+		return s.index, nil
+	}
+	index, err := s.buildIndex(ctx, size, modTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build index for: %v", s.URL)
+	}
+	if err = s.writeIndex(ctx, index); err != nil {
+		return nil, errors.Wrapf(err, "failed to persist index for: %v", s.URL)
+	}
+	s.index = index
+	return s.index, nil
+}
+
+//buildIndex walks archive content recording entry offsets
+func (s *storager) buildIndex(ctx context.Context, size int64, modTime time.Time) (*Index, error) {
+	index := &Index{Size: size, ModTime: modTime}
+	compressed, err := s.walker.walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader, offset int64) (bool, error) {
+		index.Entries = append(index.Entries, newEntry(parent, info, offset))
+		return true, nil
+	})
+	index.Compressed = compressed
+	return index, err
+}
+
+func (s *storager) indexManager() storage.Manager {
+	if s.indexOption.Manager != nil {
+		return s.indexOption.Manager
+	}
+	return s.manager
+}
+
+func (s *storager) readIndex(ctx context.Context) (*Index, error) {
+	if s.indexOption.URL == "" {
+		return nil, errors.New("index URL was empty")
+	}
+	reader, err := s.indexManager().OpenURL(ctx, s.indexOption.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	index := &Index{}
+	return index, json.NewDecoder(reader).Decode(index)
+}
+
+func (s *storager) writeIndex(ctx context.Context, index *Index) error {
+	if s.indexOption.URL == "" {
+		return nil
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return s.indexManager().Upload(ctx, s.indexOption.URL, file.DefaultFileOsMode, bytes.NewReader(data))
+}
+
+//openEntry opens entry content directly if archive reader supports random access
+func (s *storager) openEntry(ctx context.Context, index *Index, entry *Entry) (io.ReadCloser, bool) {
+	if index.Compressed || entry.Offset < 0 {
+		return nil, false
+	}
+	reader, err := s.downloader.OpenURL(ctx, s.URL)
+	if err != nil {
+		return nil, false
+	}
+	if readerAt, ok := reader.(io.ReaderAt); ok {
+		return &readCloser{Reader: io.NewSectionReader(readerAt, entry.Offset, entry.Size), Closer: reader}, true
+	}
+	if seeker, ok := reader.(io.Seeker); ok {
+		if _, err = seeker.Seek(entry.Offset, io.SeekStart); err == nil {
+			return &readCloser{Reader: io.LimitReader(reader, entry.Size), Closer: reader}, true
+		}
+	}
+	_ = reader.Close()
+	return nil, false
+}
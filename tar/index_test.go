@@ -0,0 +1,177 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func newTestArchive(t *testing.T, compressed bool, files map[string]string) []byte {
+	buffer := new(bytes.Buffer)
+	writer := tar.NewWriter(buffer)
+	for _, name := range []string{"folder1/res1.txt", "folder1/res2.txt", "folder2/sub/res3.txt", "res4.txt"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		err := writer.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.Nil(t, err)
+		_, err = writer.Write([]byte(content))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, writer.Close())
+	if !compressed {
+		return buffer.Bytes()
+	}
+	compressedBuffer := new(bytes.Buffer)
+	gzWriter := gzip.NewWriter(compressedBuffer)
+	_, _ = gzWriter.Write(buffer.Bytes())
+	_ = gzWriter.Close()
+	return compressedBuffer.Bytes()
+}
+
+func TestStorager_Index(t *testing.T) {
+	ctx := context.Background()
+	files := map[string]string{
+		"folder1/res1.txt":     "this is test 1",
+		"folder1/res2.txt":     "this is test 2",
+		"folder2/sub/res3.txt": "this is test 3",
+		"res4.txt":             "this is test 4",
+	}
+	tempDir := os.TempDir()
+	memManager := mem.Singleton()
+
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		archiveURL  string
+		compressed  bool
+		index       *option.ArchiveIndex
+		listPath    string
+		listCount   int
+		random      bool
+	}{
+		{
+			description: "file archive with in memory index",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_index_001.tar"),
+			index:       option.NewArchiveIndex("", nil),
+			listPath:    "folder1",
+			listCount:   3,
+			random:      true,
+		},
+		{
+			description: "file archive with mem persisted index",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_index_002.tar"),
+			index:       option.NewArchiveIndex("mem://localhost/index/afs_index_002.tar.idx", memManager),
+			listPath:    "",
+			listCount:   4,
+			random:      true,
+		},
+		{
+			description: "mem archive with index persisted next to archive",
+			manager:     memManager,
+			archiveURL:  "mem://localhost/data/afs_index_003.tar",
+			index:       option.NewArchiveIndex("mem://localhost/data/afs_index_003.tar.idx", nil),
+			listPath:    "folder2",
+			listCount:   2,
+		},
+		{
+			description: "compressed mem archive with index",
+			manager:     memManager,
+			archiveURL:  "mem://localhost/data/afs_index_004.tgz",
+			compressed:  true,
+			index:       option.NewArchiveIndex("", nil),
+			listPath:    "folder2/sub",
+			listCount:   2,
+		},
+	}
+
+	for _, useCase := range useCases {
+		err := useCase.manager.Upload(ctx, useCase.archiveURL, 0644, bytes.NewReader(newTestArchive(t, useCase.compressed, files)))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		baseURL := strings.Replace(useCase.archiveURL, "://", ":", 1) + "/tar://localhost/"
+		storager, err := newStorager(ctx, baseURL, useCase.manager, useCase.index)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		objects, err := storager.List(ctx, useCase.listPath)
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, useCase.listCount, len(objects), useCase.description)
+		if !assert.NotNil(t, storager.index, useCase.description) {
+			continue
+		}
+		assert.Equal(t, useCase.compressed, storager.index.Compressed, useCase.description)
+
+		for name, content := range files {
+			ok, err := storager.Exists(ctx, name)
+			assert.Nil(t, err, useCase.description)
+			assert.True(t, ok, useCase.description+" "+name)
+
+			entry := storager.index.Lookup(name)
+			if assert.NotNil(t, entry, useCase.description) {
+				_, random := storager.openEntry(ctx, storager.index, entry)
+				assert.Equal(t, useCase.random, random, useCase.description+" "+name)
+			}
+			reader, err := storager.Open(ctx, name)
+			if !assert.Nil(t, err, useCase.description) {
+				continue
+			}
+			data, err := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.Nil(t, err, useCase.description)
+			assert.Equal(t, content, string(data), useCase.description+" "+name)
+		}
+		_, err = storager.Open(ctx, "folder1/missing.txt")
+		assert.NotNil(t, err, useCase.description)
+
+		if useCase.index.URL != "" {
+			reloaded, err := newStorager(ctx, baseURL, useCase.manager, useCase.index)
+			assert.Nil(t, err, useCase.description)
+			persisted, err := reloaded.readIndex(ctx)
+			if assert.Nil(t, err, useCase.description) {
+				assert.Equal(t, len(storager.index.Entries), len(persisted.Entries), useCase.description)
+			}
+		}
+
+		err = storager.Upload(ctx, "folder1/res5.txt", 0644, bytes.NewReader([]byte("this is test 5")))
+		assert.Nil(t, err, useCase.description)
+		reader, err := storager.Open(ctx, "folder1/res5.txt")
+		if assert.Nil(t, err, useCase.description) {
+			data, _ := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.Equal(t, "this is test 5", string(data), useCase.description)
+		}
+		objects, err = storager.List(ctx, "folder1")
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, 4, len(objects), useCase.description)
+
+		replaced := map[string]string{"folder1/res1.txt": "this is replaced test 1"}
+		err = useCase.manager.Upload(ctx, useCase.archiveURL, 0644, bytes.NewReader(newTestArchive(t, useCase.compressed, replaced)))
+		assert.Nil(t, err, useCase.description)
+		objects, err = storager.List(ctx, "folder1")
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, 2, len(objects), useCase.description)
+		reader, err = storager.Open(ctx, "folder1/res1.txt")
+		if assert.Nil(t, err, useCase.description) {
+			data, _ := ioutil.ReadAll(reader)
+			_ = reader.Close()
+			assert.Equal(t, replaced["folder1/res1.txt"], string(data), useCase.description)
+		}
+		_ = useCase.manager.Delete(ctx, useCase.archiveURL)
+	}
+}
@@ -0,0 +1,61 @@
+package tar
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/afs/archive"
+	"github.com/viant/afs/iterator"
+	"github.com/viant/afs/object"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+//ListIter returns an iterator streaming archive entries (archive order), continuation token resumes after the returned entries count
+func (m *manager) ListIter(ctx context.Context, URL string, options ...storage.Option) (storage.Iterator, error) {
+	token, err := iterator.Continuation(options)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, URLPath := url.Base(URL, Scheme)
+	srv, err := m.Storager(ctx, baseURL, options)
+	if err != nil {
+		return nil, err
+	}
+	service, ok := srv.(*storager)
+	if !ok {
+		return nil, fmt.Errorf("unsupported storager type: expected: %T, but had %T", service, srv)
+	}
+	if !service.exists {
+		return nil, fmt.Errorf("%v: not found", service.URL)
+	}
+	return iterator.NewStream(ctx, func(ctx context.Context, emit func(object storage.Object) bool) error {
+		return service.iterate(ctx, URLPath, options, func(parent string, info os.FileInfo) bool {
+			return emit(object.New(url.Join(baseURL, path.Join(parent, info.Name())), info, nil))
+		})
+	}, token), nil
+}
+
+//iterate visits the same archive entries as List, excluding listed directory itself
+func (s *storager) iterate(ctx context.Context, location string, options []storage.Option, visit func(parent string, info os.FileInfo) bool) error {
+	listing := archive.NewListing(strings.Trim(location, "/"))
+	match, _ := option.GetListOptions(options)
+	return s.walker.Walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
+		for _, entry := range listing.Entries(parent, info) {
+			if !match(entry.Parent, entry.FileInfo) {
+				continue
+			}
+			if entry.IsLocation && entry.IsDir() {
+				continue
+			}
+			if !visit(entry.Parent, entry.FileInfo) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,160 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"github.com/viant/afs/archive"
+	"github.com/viant/afs/storage"
+	"io"
+	"strings"
+)
+
+const blockSize = 512
+
+//modify applies changes in a single archive pass, entries are streamed, additions are appended at the end of archive
+func (s *storager) modify(ctx context.Context, changes *archive.Changes) error {
+	defer s.resetIndex()
+	if appended, err := s.append(ctx, changes); appended || err != nil {
+		return err
+	}
+	return archive.Modify(ctx, s.manager, s.URL, s.mode, func(base io.ReaderAt, size int64, writer io.Writer) error {
+		return rewrite(base, size, writer, changes)
+	})
+}
+
+//append writes added entries and a new trailer in place of the archive trailer, it returns false if manager does not support in place writes or changes do not only append entries
+func (s *storager) append(ctx context.Context, changes *archive.Changes) (bool, error) {
+	overwriter, ok := s.manager.(storage.Overwriter)
+	if !ok {
+		return false, nil
+	}
+	appended := false
+	err := overwriter.Overwrite(ctx, s.URL, func(base io.ReaderAt, size int64, writer io.WriteSeeker) error {
+		magic := make([]byte, len(gzipMagic))
+		if n, _ := base.ReadAt(magic, 0); n == len(magic) && bytes.Equal(magic, gzipMagic) {
+			return nil
+		}
+		end, existing, err := appendOffset(io.NewSectionReader(base, 0, size), changes)
+		if err != nil || existing == nil {
+			return err
+		}
+		appended = true
+		if _, err = writer.Seek(end, io.SeekStart); err != nil {
+			return err
+		}
+		return appendEntries(tar.NewWriter(writer), changes, existing)
+	})
+	return appended, err
+}
+
+func rewrite(base io.ReaderAt, size int64, dest io.Writer, changes *archive.Changes) error {
+	magic := make([]byte, len(gzipMagic))
+	if n, _ := base.ReadAt(magic, 0); n == len(magic) && bytes.Equal(magic, gzipMagic) {
+		reader, err := gzip.NewReader(io.NewSectionReader(base, 0, size))
+		if err != nil {
+			return err
+		}
+		writer := gzip.NewWriter(dest)
+		if err = copyEntries(reader, writer, changes); err != nil {
+			return err
+		}
+		return writer.Close()
+	}
+	end, existing, err := appendOffset(io.NewSectionReader(base, 0, size), changes)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if _, err = io.Copy(dest, io.NewSectionReader(base, 0, end)); err != nil {
+			return err
+		}
+		return appendEntries(tar.NewWriter(dest), changes, existing)
+	}
+	return copyEntries(io.NewSectionReader(base, 0, size), dest, changes)
+}
+
+//appendOffset returns the end offset of the last archive entry and entry names if changes only append entries
+func appendOffset(reader *io.SectionReader, changes *archive.Changes) (int64, map[string]bool, error) {
+	tarReader := tar.NewReader(reader)
+	existing := make(map[string]bool)
+	end := int64(0)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF || header == nil {
+			break
+		}
+		name, ok := changes.Rename(header.Name)
+		if !ok || name != strings.Trim(header.Name, "/") {
+			return 0, nil, nil
+		}
+		existing[name] = true
+		offset, err := reader.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, nil, err
+		}
+		end = offset + (header.Size+blockSize-1)/blockSize*blockSize
+	}
+	return end, existing, nil
+}
+
+//copyEntries streams retained entries, moved entries are renamed
+func copyEntries(source io.Reader, dest io.Writer, changes *archive.Changes) error {
+	reader := tar.NewReader(source)
+	writer := tar.NewWriter(dest)
+	existing := make(map[string]bool)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF || header == nil {
+			break
+		}
+		name, ok := changes.Rename(header.Name)
+		if !ok {
+			continue
+		}
+		existing[name] = true
+		if name != strings.Trim(header.Name, "/") {
+			header.Name = name
+			if header.Typeflag == tar.TypeDir {
+				header.Name += "/"
+			}
+			header.Format = tar.FormatUnknown
+		}
+		if err = writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err = io.Copy(writer, reader); err != nil {
+				return err
+			}
+		}
+	}
+	return appendEntries(writer, changes, existing)
+}
+
+//appendEntries writes added entries and archive trailer
+func appendEntries(writer *tar.Writer, changes *archive.Changes, existing map[string]bool) error {
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+	for _, resource := range changes.Additions(existing) {
+		header, err := newHeader(resource.Name, resource.Info())
+		if err != nil {
+			return err
+		}
+		content, size := changes.Content(resource)
+		if header.Typeflag == tar.TypeReg {
+			header.Size = size
+		}
+		if err = writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err = io.Copy(writer, content); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Close()
+}
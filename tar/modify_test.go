@@ -0,0 +1,171 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/storage"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func readTestArchive(t *testing.T, data []byte) map[string]string {
+	var reader io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(data, gzipMagic) {
+		gzReader, err := gzip.NewReader(reader)
+		assert.Nil(t, err)
+		reader = gzReader
+	}
+	var result = make(map[string]string)
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.Nil(t, err) {
+			break
+		}
+		content, _ := ioutil.ReadAll(tarReader)
+		result[header.Name] = string(content)
+	}
+	return result
+}
+
+func TestStorager_Modify(t *testing.T) {
+	ctx := context.Background()
+	tempDir := os.TempDir()
+	files := map[string]string{
+		"folder1/res1.txt": "this is test 1",
+		"folder1/res2.txt": "this is test 2",
+		"res4.txt":         "this is test 4",
+	}
+
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		archiveURL  string
+		compressed  bool
+		modify      func(storager *storager) error
+		expect      map[string]string
+		appended    bool
+		inPlace     bool
+		hasError    bool
+	}{
+		{
+			description: "append entry at the end of archive",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_tar_modify_001.tar"),
+			modify: func(storager *storager) error {
+				return storager.Upload(ctx, "folder2/res5.txt", 0644, strings.NewReader("this is test 5"))
+			},
+			expect: map[string]string{
+				"folder1/res1.txt": "this is test 1",
+				"folder1/res2.txt": "this is test 2",
+				"res4.txt":         "this is test 4",
+				"folder2/":         "",
+				"folder2/res5.txt": "this is test 5",
+			},
+			appended: true,
+			inPlace:  true,
+		},
+		{
+			description: "delete folder",
+			manager:     mem.New(),
+			archiveURL:  "mem://localhost/data/afs_tar_modify_002.tar",
+			modify: func(storager *storager) error {
+				return storager.Delete(ctx, "folder1")
+			},
+			expect: map[string]string{
+				"res4.txt": "this is test 4",
+			},
+		},
+		{
+			description: "move entry in compressed archive",
+			manager:     mem.New(),
+			archiveURL:  "mem://localhost/data/afs_tar_modify_003.tgz",
+			compressed:  true,
+			modify: func(storager *storager) error {
+				return storager.Move(ctx, "folder1/res1.txt", "res1.txt")
+			},
+			expect: map[string]string{
+				"res1.txt":         "this is test 1",
+				"folder1/res2.txt": "this is test 2",
+				"res4.txt":         "this is test 4",
+			},
+		},
+		{
+			description: "replace entry",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_tar_modify_004.tar"),
+			modify: func(storager *storager) error {
+				return storager.Upload(ctx, "folder1/res1.txt", 0644, strings.NewReader("this is test 1 updated"))
+			},
+			expect: map[string]string{
+				"folder1/res1.txt": "this is test 1 updated",
+				"folder1/res2.txt": "this is test 2",
+				"res4.txt":         "this is test 4",
+				"folder1/":         "",
+			},
+		},
+		{
+			description: "delete missing entry",
+			manager:     mem.New(),
+			archiveURL:  "mem://localhost/data/afs_tar_modify_005.tar",
+			modify: func(storager *storager) error {
+				return storager.Delete(ctx, "folder3")
+			},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		original := newTestArchive(t, useCase.compressed, files)
+		err := useCase.manager.Upload(ctx, useCase.archiveURL, 0644, bytes.NewReader(original))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		baseURL := strings.Replace(useCase.archiveURL, "://", ":", 1) + "/tar://localhost/"
+		if !strings.Contains(useCase.archiveURL, "://") {
+			baseURL = "file:" + useCase.archiveURL + "/tar://localhost/"
+		}
+		storager, err := newStorager(ctx, baseURL, useCase.manager)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		before, _ := os.Stat(useCase.archiveURL)
+		err = useCase.modify(storager)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := useCase.manager.OpenURL(ctx, useCase.archiveURL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, _ := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		assert.Equal(t, useCase.compressed, bytes.HasPrefix(data, gzipMagic), useCase.description)
+		assert.EqualValues(t, useCase.expect, readTestArchive(t, data), useCase.description)
+		if useCase.appended {
+			trailer := 2 * blockSize
+			assert.True(t, bytes.HasPrefix(data, original[:len(original)-trailer]), useCase.description)
+		}
+		if useCase.inPlace {
+			after, _ := os.Stat(useCase.archiveURL)
+			assert.True(t, before != nil && os.SameFile(before, after), useCase.description)
+		}
+		_ = useCase.manager.Delete(ctx, useCase.archiveURL)
+	}
+}
@@ -1,7 +1,7 @@
 package tar
 
 import (
-	"archive/zip"
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
@@ -17,8 +17,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
-	"path"
 	"strings"
+	"sync"
 )
 
 type storager struct {
@@ -31,6 +31,11 @@ type storager struct {
 	closer     io.Closer
 	uploader   storage.Uploader
 	downloader storage.Opener
+	manager    storage.Manager
+	//optional entries index
+	indexOption *option.ArchiveIndex
+	index       *Index
+	mutex       sync.Mutex
 }
 
 //Exists returns true if resource exists in archive
@@ -46,25 +51,38 @@ func (s *storager) List(ctx context.Context, location string, options ...storage
 	}
 	var result = make([]os.FileInfo, 0)
 	location = strings.Trim(location, "/")
-	basicMatcher, _ := matcher.NewBasic(location, "", "", nil)
+	listing := archive.NewListing(location)
 	match, page := option.GetListOptions(options)
-
-	err := s.walker.Walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
-		if !basicMatcher.Match(parent, info) {
-			return true, nil
-		}
-		if !match(parent, info) {
-			return true, nil
-		}
-		page.Increment()
-		if page.ShallSkip() {
-			return true, nil
+	visit := func(parent string, info os.FileInfo) bool {
+		for _, entry := range listing.Entries(parent, info) {
+			if !match(entry.Parent, entry.FileInfo) {
+				continue
+			}
+			page.Increment()
+			if page.ShallSkip() {
+				continue
+			}
+			result = append(result, entry.FileInfo)
+			if page.HasReachedLimit() {
+				return false
+			}
 		}
-		result = append(result, info)
-		if page.HasReachedLimit() {
-			return false, nil
+		return true
+	}
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		for _, entry := range index.Entries {
+			if !visit(entry.Parent, entry.Info()) {
+				break
+			}
 		}
-		return true, nil
+		return result, nil
+	}
+	err = s.walker.Walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
+		return visit(parent, info), nil
 	})
 	return result, err
 }
@@ -101,23 +119,20 @@ func (s *storager) Open(ctx context.Context, location string, options ...storage
 		return nil, fmt.Errorf("%v: not found", s.URL)
 	}
 	location = strings.Trim(location, "/")
-	var result io.ReadCloser
-	err := s.walker.Walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
-		filename := path.Join(parent, info.Name())
-		if location == filename {
-			data, err := ioutil.ReadAll(reader)
-			if err != nil {
-				return false, err
-			}
-			result = ioutil.NopCloser(bytes.NewReader(data))
-			return false, nil
+	index, err := s.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index != nil {
+		entry := index.Lookup(location)
+		if entry == nil {
+			return nil, fmt.Errorf("%v: not found in archive: %v", location, s.URL)
+		}
+		if reader, ok := s.openEntry(ctx, index, entry); ok {
+			return reader, nil
 		}
-		return true, nil
-	})
-	if err == nil && result == nil {
-		return nil, fmt.Errorf("%v: not found in archive: %v", location, s.URL)
 	}
-	return result, err
+	return s.walker.openEntry(ctx, s.URL, location)
 }
 
 //Delete removes specified resource from archive
@@ -125,27 +140,37 @@ func (s *storager) Delete(ctx context.Context, location string, options ...stora
 	if !s.exists {
 		return fmt.Errorf("%v: not found", s.URL)
 	}
-	location = strings.Trim(location, "/")
-	uploader := newBatchUploader(nil)
-	upload, closer, err := uploader.Uploader(ctx, "")
-	if err != nil {
+	changes := archive.NewChanges()
+	changes.Delete(location)
+	if err := s.modify(ctx, changes); err != nil {
 		return errors.Wrapf(err, "failed to delete: %v in archive: %v", location, s.URL)
 	}
+	return nil
+}
 
-	err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.DeleteHandler(location))
-	if err == nil {
-		err = closer.Close()
+//Move moves specified resource within archive
+func (s *storager) Move(ctx context.Context, source, dest string, options ...storage.Option) error {
+	if !s.exists {
+		return fmt.Errorf("%v: not found", s.URL)
 	}
-	if err != nil {
-		return err
+	changes := archive.NewChanges()
+	changes.Move(source, dest)
+	if err := s.modify(ctx, changes); err != nil {
+		return errors.Wrapf(err, "failed to move: %v to %v in archive: %v", source, dest, s.URL)
 	}
-	return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+	return nil
+}
+
+//resetIndex discards in memory index, persisted index is detected as stale on the next load
+func (s *storager) resetIndex() {
+	s.mutex.Lock()
+	s.index = nil
+	s.mutex.Unlock()
 }
 
 func (s *storager) touch(ctx context.Context) error {
 	buffer := new(bytes.Buffer)
-	writer := zip.NewWriter(buffer)
-	_ = writer.Flush()
+	writer := tar.NewWriter(buffer)
 	_ = writer.Close()
 	err := s.uploader.Upload(ctx, s.URL, s.mode, buffer)
 	if err == nil {
@@ -162,22 +187,11 @@ func (s *storager) Uploader(ctx context.Context, destination string) (storage.Up
 		}
 	}
 	destination = strings.Trim(destination, "/")
-	uploader := archive.NewRewriteUploader(func(resources []*asset.Resource) error {
-		uploader := newBatchUploader(nil)
-		upload, closer, err := uploader.Uploader(ctx, "")
-		if err != nil {
+	uploader := archive.NewSpoolUploader(destination, func(changes *archive.Changes) error {
+		if err := s.modify(ctx, changes); err != nil {
 			return errors.Wrapf(err, "failed to upload: %v in archive: %v", destination, s.URL)
 		}
-		resources = archive.UpdateDestination(destination, resources)
-		err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.UploadHandler(resources))
-		if err == nil {
-			err = closer.Close()
-		}
-		if err != nil {
-			return err
-		}
-		s.walker.data = uploader.buffer.Bytes()
-		return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+		return nil
 	})
 	return uploader.Upload, uploader, nil
 }
@@ -195,28 +209,19 @@ func (s *storager) Create(ctx context.Context, destination string, mode os.FileM
 		}
 	}
 	destination = strings.Trim(destination, "/")
-	uploader := newBatchUploader(nil)
-	upload, closer, err := uploader.Uploader(ctx, "")
-	if err != nil {
-		return errors.Wrapf(err, "failed to create: %v in archive: %v", destination, s.URL)
-	}
-
 	var content []byte
+	var err error
 	if reader != nil {
 		if content, err = ioutil.ReadAll(reader); err != nil {
 			return err
 		}
 	}
-
-	err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.CreateHandler(destination, mode, content, isDir))
-	if err == nil {
-		err = closer.Close()
-	}
-	if err != nil {
-		return err
+	changes := archive.NewChanges()
+	changes.Add(asset.New(destination, mode, isDir, "", content))
+	if err = s.modify(ctx, changes); err != nil {
+		return errors.Wrapf(err, "failed to create: %v in archive: %v", destination, s.URL)
 	}
-	s.walker.data = uploader.buffer.Bytes()
-	return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+	return nil
 }
 
 //Close closes undelrying closer
@@ -225,7 +230,7 @@ func (s *storager) Close() error {
 }
 
 //newStorager create a storage service
-func newStorager(ctx context.Context, baseURL string, mgr storage.Manager) (*storager, error) {
+func newStorager(ctx context.Context, baseURL string, mgr storage.Manager, options ...storage.Option) (*storager, error) {
 	URL := url.SchemeExtensionURL(baseURL)
 	if URL == "" {
 		return nil, fmt.Errorf("invalid URL: %v", baseURL)
@@ -245,8 +250,10 @@ func newStorager(ctx context.Context, baseURL string, mgr storage.Manager) (*sto
 		mode:       mode,
 		uploader:   mgr,
 		downloader: mgr,
+		manager:    mgr,
 		URL:        URL,
 	}
+	option.Assign(options, &result.indexOption)
 	result.Storager.List = result.List
 	return result, nil
 }
@@ -3,14 +3,19 @@ package tar
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/afs/asset"
+	"github.com/viant/afs/file"
 	"github.com/viant/afs/mem"
 	"github.com/viant/afs/storage"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -102,3 +107,101 @@ func TestNewStorager(t *testing.T) {
 	}
 
 }
+
+func TestStorager_List(t *testing.T) {
+	ctx := context.Background()
+	baseDir, _ := os.Getwd()
+	storager, err := newStorager(ctx, fmt.Sprintf("file:%v/test/test.%v/%v://localhost/", baseDir, Scheme, Scheme), file.New())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+	}{
+		{description: "root", location: "", expect: []string{"test"}},
+		{description: "folder direct children", location: "test", expect: []string{"", "asset1.txt", "asset2.txt", "folder1", "folder2"}},
+		{description: "nested folder", location: "test/folder2", expect: []string{"", "res1.txt"}},
+		{description: "file", location: "test/asset1.txt", expect: []string{"asset1.txt"}},
+	}
+	for _, useCase := range useCases {
+		objects, err := storager.List(ctx, useCase.location)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual []string
+		for _, object := range objects {
+			actual = append(actual, object.Name())
+		}
+		sort.Strings(actual)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestStorager_Open(t *testing.T) {
+	ctx := context.Background()
+	content := new(bytes.Buffer)
+	for i := 0; i < 10000; i++ {
+		content.WriteString(fmt.Sprintf("%x\n", md5.Sum([]byte(fmt.Sprint(i)))))
+	}
+	files := map[string]string{
+		"folder1/res1.txt": "this is test 1",
+		"res4.txt":         content.String(),
+	}
+
+	var useCases = []struct {
+		description string
+		URL         string
+		compressed  bool
+		location    string
+		expect      string
+	}{
+		{description: "first entry is streamed", URL: "mem://localhost/open/afs_open_001.tar", location: "folder1/res1.txt", expect: files["folder1/res1.txt"]},
+		{description: "compressed first entry is streamed", URL: "mem://localhost/open/afs_open_002.tgz", compressed: true, location: "folder1/res1.txt", expect: files["folder1/res1.txt"]},
+	}
+	for _, useCase := range useCases {
+		data := newTestArchive(t, useCase.compressed, files)
+		manager := &readCounter{Manager: mem.New()}
+		if !assert.Nil(t, manager.Upload(ctx, useCase.URL, 0644, bytes.NewReader(data)), useCase.description) {
+			continue
+		}
+		storager, err := newStorager(ctx, strings.Replace(useCase.URL, "://", ":", 1)+"/tar://localhost/", manager)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := storager.Open(ctx, useCase.location)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err, useCase.description)
+		assert.Nil(t, reader.Close(), useCase.description)
+		assert.EqualValues(t, useCase.expect, string(actual), useCase.description)
+		assert.True(t, manager.read < int64(len(data)), useCase.description)
+	}
+}
+
+//readCounter counts bytes read from opened content
+type readCounter struct {
+	storage.Manager
+	read int64
+}
+
+func (c *readCounter) OpenURL(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
+	reader, err := c.Manager.OpenURL(ctx, URL, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &readCloser{Reader: io.TeeReader(reader, writerFunc(func(p []byte) (int, error) {
+		c.read += int64(len(p))
+		return len(p), nil
+	})), Closer: reader}, nil
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
@@ -28,22 +28,10 @@ func (u *uploader) Uploader(ctx context.Context, URL string, options ...storage.
 	}
 	writer := newWriter(ctx, u.buffer, URL, uploader)
 	return func(ctx context.Context, parent string, info os.FileInfo, reader io.Reader) error {
-		link := ""
-		var options []storage.Option
-		if fileInfo, ok := info.(*file.Info); ok {
-			link = fileInfo.Linkname
-			options = make([]storage.Option, 0)
-			options = append(options, fileInfo.Link)
-		}
-		filename := path.Join(parent, info.Name())
-		info = file.NewInfo(filename, info.Size(), info.Mode(), info.ModTime(), info.IsDir(), options...)
-		header, err := tar.FileInfoHeader(info, link)
+		header, err := newHeader(path.Join(parent, info.Name()), info)
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			header.Typeflag = tar.TypeDir
-		}
 		if err = writer.WriteHeader(header); err != nil {
 			return err
 		}
@@ -55,6 +43,26 @@ func (u *uploader) Uploader(ctx context.Context, URL string, options ...storage.
 	}, writer, nil
 }
 
+//newHeader returns tar header for supplied archive filename
+func newHeader(filename string, info os.FileInfo) (*tar.Header, error) {
+	link := ""
+	var options []storage.Option
+	if fileInfo, ok := info.(*file.Info); ok {
+		link = fileInfo.Linkname
+		options = make([]storage.Option, 0)
+		options = append(options, fileInfo.Link)
+	}
+	info = file.NewInfo(filename, info.Size(), info.Mode(), info.ModTime(), info.IsDir(), options...)
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		header.Typeflag = tar.TypeDir
+	}
+	return header, nil
+}
+
 //newBatchUploader returns a batch uploader
 func newBatchUploader(dest storage.Uploader) *uploader {
 	return &uploader{uploader: dest}
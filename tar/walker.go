@@ -2,50 +2,37 @@ package tar
 
 import (
 	"archive/tar"
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
 	"fmt"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/object"
-	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
 	"github.com/viant/afs/url"
 	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"strings"
 )
 
+var gzipMagic = []byte{0x1f, 0x8b}
+
 type walker struct {
 	storage.Opener
-	data []byte
-	URL  string
 }
 
-func (w *walker) open(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, error) {
-	if len(w.data) > 0 && URL == w.URL {
-		return ioutil.NopCloser(bytes.NewReader(w.data)), nil
-	}
-	rawReader, err := w.OpenURL(ctx, URL, options...)
-	if err != nil {
-		return nil, err
-	}
+//onEntry represents archive entry visitor with entry content offset in uncompressed archive
+type onEntry func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader, offset int64) (bool, error)
 
-	size := option.Size(0)
-	option.Assign(options, &size)
-	if size > 0 {
-		return rawReader, nil
-	}
-	defer rawReader.Close()
-	data, err := ioutil.ReadAll(rawReader)
+//open streams archive content, gzip compressed archive is decompressed, it returns true if archive was compressed
+func (w *walker) open(ctx context.Context, URL string, options ...storage.Option) (io.ReadCloser, bool, error) {
+	rawReader, err := w.OpenURL(ctx, URL, options...)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	w.URL = URL
-	w.data = data
-	return ioutil.NopCloser(bytes.NewReader(w.data)), nil
+	return uncompressIfNeeded(rawReader)
 }
 
 func (w *walker) fetch(reader *tar.Reader, location string, cache map[string][]byte) (io.Reader, error) {
@@ -81,23 +68,26 @@ func (w *walker) buildCache(reader *tar.Reader, cache map[string][]byte) {
 	}
 }
 
+//Walk visits archive entries
 func (w *walker) Walk(ctx context.Context, URL string, handler storage.OnVisit, options ...storage.Option) error {
-	URL = url.Normalize(URL, file.Scheme)
-	readerCloser, err := w.open(ctx, URL, options...)
-	if strings.HasSuffix(URL, ".gz") {
-		if readerCloser, err = w.uncompressIfNeeded(readerCloser); err != nil {
-			return err
-		}
-
-	}
+	_, err := w.walk(ctx, URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader, offset int64) (bool, error) {
+		return handler(ctx, baseURL, parent, info, reader)
+	}, options...)
+	return err
+}
 
+//walk visits archive entries with their content offset, it returns true if archive was compressed
+func (w *walker) walk(ctx context.Context, URL string, handler onEntry, options ...storage.Option) (bool, error) {
+	URL = url.Normalize(URL, file.Scheme)
+	readerCloser, compressed, err := w.open(ctx, URL, options...)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer readerCloser.Close()
 	var shallContinue bool
 	var ioReader io.Reader
-	reader := tar.NewReader(readerCloser)
+	counter := &countingReader{Reader: readerCloser}
+	reader := tar.NewReader(counter)
 	//cache is only used if sym link are used
 	var cache = make(map[string][]byte)
 
@@ -107,12 +97,16 @@ func (w *walker) Walk(ctx context.Context, URL string, handler storage.OnVisit,
 		if err == io.EOF || header == nil {
 			break
 		}
+		offset := counter.count
+		visit := func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+			return handler(ctx, baseURL, parent, info, reader, offset)
+		}
 		relative, name := path.Split(header.Name)
 		if _, ok := oldRelative[relative]; header.Typeflag != tar.TypeDir && !ok {
 			info := file.NewInfo("", 0, file.DefaultDirOsMode, header.ModTime, true)
-			_, err = handler(ctx, URL, relative, info, nil)
+			_, err = visit(ctx, URL, relative, info, nil)
 			if err != nil {
-				return err
+				return compressed, err
 			}
 		}
 		oldRelative[relative] = true
@@ -120,42 +114,96 @@ func (w *walker) Walk(ctx context.Context, URL string, handler storage.OnVisit,
 		info := file.NewInfo(name, header.Size, os.FileMode(mode), header.ModTime, header.Typeflag == tar.TypeDir)
 		switch header.Typeflag {
 		case tar.TypeDir:
-			shallContinue, err = handler(ctx, URL, relative, info, nil)
+			shallContinue, err = visit(ctx, URL, relative, info, nil)
 		case tar.TypeReg:
-			shallContinue, err = visitRegularHeader(ctx, reader, handler, URL, relative, info)
+			shallContinue, err = visitRegularHeader(ctx, reader, visit, URL, relative, info)
 		case tar.TypeSymlink:
 			linkPath := path.Clean(path.Join(relative, header.Linkname))
-			linkReader, err := w.open(ctx, URL, options...)
+			linkReader, _, err := w.open(ctx, URL, options...)
 			if err != nil {
-				return err
+				return compressed, err
 			}
 			if ioReader, err = w.fetch(tar.NewReader(linkReader), linkPath, cache); err == nil {
-				shallContinue, err = visitSymlinkHeader(ctx, header, linkPath, ioReader, handler, URL, relative, info)
+				shallContinue, err = visitSymlinkHeader(ctx, header, linkPath, ioReader, visit, URL, relative, info)
 			}
 			linkReader.Close()
 		default:
-			return fmt.Errorf("unknown header type: %v", header.Typeflag)
+			return compressed, fmt.Errorf("unknown header type: %v", header.Typeflag)
 		}
 		if err != nil || !shallContinue {
-			return err
+			return compressed, err
 		}
 	}
-	return nil
+	return compressed, nil
 }
 
-func (w *walker) uncompressIfNeeded(readerCloser io.ReadCloser) (io.ReadCloser, error) {
-	data, err := ioutil.ReadAll(readerCloser)
+//openEntry streams supplied archive location content, symbolic links are followed
+func (w *walker) openEntry(ctx context.Context, URL string, location string, options ...storage.Option) (io.ReadCloser, error) {
+	URL = url.Normalize(URL, file.Scheme)
+	readerCloser, _, err := w.open(ctx, URL, options...)
 	if err != nil {
 		return nil, err
 	}
+	reader := tar.NewReader(readerCloser)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF || header == nil {
+			break
+		}
+		if err != nil {
+			_ = readerCloser.Close()
+			return nil, err
+		}
+		if strings.Trim(header.Name, "/") != location {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeReg:
+			return &readCloser{Reader: reader, Closer: readerCloser}, nil
+		case tar.TypeSymlink:
+			_ = readerCloser.Close()
+			relative, _ := path.Split(header.Name)
+			return w.openEntry(ctx, URL, path.Clean(path.Join(relative, header.Linkname)), options...)
+		default:
+			_ = readerCloser.Close()
+			return nil, fmt.Errorf("%v: is not a regular file in archive: %v", location, URL)
+		}
+	}
 	_ = readerCloser.Close()
-	gzReader, err := gzip.NewReader(bytes.NewReader(data))
+	return nil, fmt.Errorf("%v: not found in archive: %v", location, URL)
+}
+
+//uncompressIfNeeded detects gzip compressed archive (.tar.gz, .tgz) and returns streaming decompressing reader
+func uncompressIfNeeded(readerCloser io.ReadCloser) (io.ReadCloser, bool, error) {
+	reader := bufio.NewReader(readerCloser)
+	magic, err := reader.Peek(2)
+	compressed := err == nil && magic[0] == gzipMagic[0] && magic[1] == gzipMagic[1]
+	if !compressed {
+		return &readCloser{Reader: reader, Closer: readerCloser}, false, nil
+	}
+	gzReader, err := gzip.NewReader(reader)
 	if err != nil {
-		return ioutil.NopCloser(bytes.NewReader(data)), nil
+		_ = readerCloser.Close()
+		return nil, true, err
 	}
-	_ = gzReader.Close()
-	data, err = ioutil.ReadAll(gzReader)
-	return ioutil.NopCloser(bytes.NewReader(data)), nil
+	return &readCloser{Reader: gzReader, Closer: readerCloser}, true, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+//countingReader tracks number of bytes read
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
 }
 
 func getFileMode(header *tar.Header) int64 {
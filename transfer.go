@@ -0,0 +1,82 @@
+package afs
+
+import (
+	"github.com/viant/afs/option"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//transfer tracks copy progress
+type transfer struct {
+	started  time.Time
+	bytes    int64
+	mux      sync.Mutex
+	stats    option.Transfer
+	progress *option.Progress
+	reported bool
+}
+
+//isTracked returns true if transfer progress or summary was requested
+func (t *transfer) isTracked() bool {
+	return t.progress != nil || t.reported
+}
+
+//reader returns reader counting transferred bytes
+func (t *transfer) reader(reader io.Reader) io.Reader {
+	if reader == nil {
+		return nil
+	}
+	return &countingReader{Reader: reader, count: &t.bytes}
+}
+
+//done records transferred file and notifies progress handler
+func (t *transfer) done(URL string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.stats.Files++
+	t.stats.Current = URL
+	if t.progress == nil || t.progress.Handler == nil {
+		return
+	}
+	snapshot := t.snapshot()
+	t.progress.Handler(&snapshot)
+}
+
+func (t *transfer) snapshot() option.Transfer {
+	result := t.stats
+	result.Bytes = atomic.LoadInt64(&t.bytes)
+	result.Elapsed = time.Since(t.started)
+	if seconds := result.Elapsed.Seconds(); seconds > 0 {
+		result.Rate = float64(result.Bytes) / seconds
+	}
+	return result
+}
+
+//summary returns transfer summary
+func (t *transfer) summary() option.Transfer {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.snapshot()
+}
+
+func newTransfer(progress *option.Progress, reported bool) *transfer {
+	return &transfer{
+		started:  time.Now(),
+		stats:    option.Transfer{TotalFiles: -1, TotalBytes: -1},
+		progress: progress,
+		reported: reported,
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	count *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	atomic.AddInt64(r.count, int64(n))
+	return n, err
+}
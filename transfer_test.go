@@ -0,0 +1,97 @@
+package afs
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestService_Copy_Transfer(t *testing.T) {
+	ctx := context.Background()
+	baseDir := os.TempDir()
+	service := New()
+	sourceURL := "mem://localhost/copy_transfer"
+	expectBytes := int64(0)
+	for i := 0; i < 20; i++ {
+		content := strings.Repeat("x", i+1)
+		expectBytes += int64(len(content))
+		if !assert.Nil(t, service.Upload(ctx, fmt.Sprintf("%v/d%v/f%02d.txt", sourceURL, i%3, i), file.DefaultFileOsMode, strings.NewReader(content))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		destURL     string
+		options     []storage.Option
+		total       bool
+	}{
+		{
+			description: "sequential copy with summary",
+			destURL:     "mem://localhost/copy_transfer_dest1",
+		},
+		{
+			description: "parallel copy with progress",
+			destURL:     "mem://localhost/copy_transfer_dest2",
+			options:     []storage.Option{option.NewParallel(4, false)},
+			total:       true,
+		},
+		{
+			description: "parallel copy to file",
+			destURL:     path.Join(baseDir, "copy_transfer_dest3"),
+			options:     []storage.Option{option.NewParallel(8, false)},
+			total:       true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		_ = service.Delete(ctx, useCase.destURL)
+		var reported []option.Transfer
+		mux := &sync.Mutex{}
+		summary := option.NewTransfer()
+		options := append(useCase.options, summary, option.NewProgress(func(transfer *option.Transfer) {
+			mux.Lock()
+			defer mux.Unlock()
+			reported = append(reported, *transfer)
+		}, useCase.total))
+		err := service.Copy(ctx, sourceURL, useCase.destURL, options...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, 20, summary.Files, useCase.description)
+		assert.EqualValues(t, expectBytes, summary.Bytes, useCase.description)
+		assert.True(t, summary.Elapsed > 0, useCase.description)
+		assert.EqualValues(t, 20, len(reported), useCase.description)
+		for i, transfer := range reported {
+			assert.EqualValues(t, i+1, transfer.Files, useCase.description)
+			assert.True(t, strings.HasPrefix(transfer.Current, sourceURL+"/d"), useCase.description)
+			if useCase.total {
+				assert.EqualValues(t, 20, transfer.TotalFiles, useCase.description)
+				assert.EqualValues(t, expectBytes, transfer.TotalBytes, useCase.description)
+			} else {
+				assert.EqualValues(t, -1, transfer.TotalFiles, useCase.description)
+			}
+		}
+		objects, err := service.List(ctx, useCase.destURL, option.NewRecursive(true))
+		assert.Nil(t, err, useCase.description)
+		files := 0
+		for _, object := range objects {
+			if !object.IsDir() {
+				files++
+			}
+		}
+		assert.EqualValues(t, 20, files, useCase.description)
+		data, err := service.DownloadWithURL(ctx, useCase.destURL+"/d1/f19.txt")
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, strings.Repeat("x", 20), string(data), useCase.description)
+		_ = service.Delete(ctx, useCase.destURL)
+	}
+}
@@ -11,7 +11,9 @@ func Base(URL string, defaultSchema string) (string, string) {
 	host := Host(URL)
 	path := Path(URL)
 	if schemaExt != "" {
-		schemaExt = strings.Replace(schemaExt, "://", ":", 1)
+		if index := strings.Index(schemaExt, "://"); index != -1 && !strings.Contains(schemaExt[:index], "/") {
+			schemaExt = strings.Replace(schemaExt, "://", ":", 1)
+		}
 		base := schemaExt + "/" + schema + "://" + host
 		return base, path
 	}
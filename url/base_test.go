@@ -44,6 +44,30 @@ func TestBase(t *testing.T) {
 			expectBaseURL: "mem://",
 			expectPath:    "/var/folders/gl/5550g3kj6tn1rbz8chqx1c61ycmmm1/",
 		},
+		{
+			description:   "zip extension",
+			URL:           "file:/tmp/app.war/zip://localhost/WEB-INF/web.xml",
+			expectBaseURL: "file:/tmp/app.war/zip://localhost",
+			expectPath:    "/WEB-INF/web.xml",
+		},
+		{
+			description:   "nested extension",
+			URL:           "file:/tmp/a.tgz/gz://localhost/tar://localhost/dir/file.txt",
+			expectBaseURL: "file:/tmp/a.tgz/gz://localhost/tar://localhost",
+			expectPath:    "/dir/file.txt",
+		},
+		{
+			description:   "extension without scheme",
+			URL:           "/tmp/a.zip/zip://localhost/x.txt",
+			expectBaseURL: "/tmp/a.zip/zip://localhost",
+			expectPath:    "/x.txt",
+		},
+		{
+			description:   "URL in query",
+			URL:           "https://host/path?redirect=http://other/a",
+			expectBaseURL: "https://host",
+			expectPath:    "/path?redirect=http://other/a",
+		},
 	}
 
 	for _, useCase := range useCases {
@@ -12,7 +12,7 @@ const (
 //Host extract host from URL
 func Host(URL string) string {
 
-	index := strings.Index(URL, "://")
+	index := schemeIndex(URL)
 	if index == -1 {
 		return Localhost
 	}
@@ -15,7 +15,7 @@ func Path(URL string) string {
 	if runtime.GOOS == "windows" {
 		location = strings.ReplaceAll(location, "\\", "/")
 	}
-	if index := strings.Index(location, "://"); index != -1 {
+	if index := schemeIndex(location); index != -1 {
 		location = string(location[index+3:])
 		index := strings.Index(location, "/")
 		if index == -1 {
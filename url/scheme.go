@@ -2,9 +2,28 @@ package url
 
 import "strings"
 
+//schemeIndex returns scheme separator index, for nested scheme extension URL (i.e. file:/tmp/a.tgz/gz://localhost/tar://localhost/dir) it is the last one
+func schemeIndex(URL string) int {
+	index := strings.Index(URL, "://")
+	if index == -1 || !strings.Contains(URL[:index], "/") {
+		return index
+	}
+	for {
+		next := strings.Index(URL[index+3:], "://")
+		if next == -1 {
+			return index
+		}
+		candidate := index + 3 + next
+		if separator := strings.LastIndex(URL[:candidate], "/"); separator <= index+3 || separator == candidate-1 {
+			return index
+		}
+		index = candidate
+	}
+}
+
 //Scheme extracts URL scheme
 func Scheme(URL, defaultSchema string) string {
-	index := strings.Index(URL, "://")
+	index := schemeIndex(URL)
 	if index == -1 {
 		return defaultSchema
 	}
@@ -26,13 +45,16 @@ func IsSchemeEquals(URL1, URL2 string) bool {
 
 //SchemeExtensionURL extract scheme extension or empty string
 func SchemeExtensionURL(URL string) string {
-	index := strings.Index(URL, "://")
+	index := schemeIndex(URL)
 	if index == -1 {
 		return ""
 	}
 	schema := string(URL[:index])
 	if index := strings.LastIndex(schema, "/"); index != -1 {
 		extension := string(schema[:index])
+		if strings.Contains(extension, "://") { //nested extension URL
+			return extension
+		}
 		return strings.Replace(extension, ":", "://", 1)
 	}
 	return ""
@@ -30,6 +30,23 @@ func TestScheme(t *testing.T) {
 			expect:       "zip",
 			extensionURL: "s3://myBucket/root/path/app.zip",
 		},
+		{
+			description:  "nested extended path",
+			URL:          "file:/tmp/a.tgz/gz://localhost/tar://localhost/dir/file.txt",
+			expect:       "tar",
+			extensionURL: "file:/tmp/a.tgz/gz://localhost",
+		},
+		{
+			description:  "nested extension URL",
+			URL:          "file:/tmp/a.tgz/gz://localhost",
+			expect:       "gz",
+			extensionURL: "file:///tmp/a.tgz",
+		},
+		{
+			description: "URL in query",
+			URL:         "https://host/path?redirect=http://other/a",
+			expect:      "https",
+		},
 	}
 
 	for _, useCase := range useCases {
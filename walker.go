@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 	"github.com/viant/afs/file"
+	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
 	"github.com/viant/afs/url"
 	"github.com/viant/afs/walker"
 )
 
-//Walk visits all location recursively within provided sourceURL
+//Walk visits all location recursively within provided sourceURL, option.Parallel replaces manager walker with list based parallel walker
 func (s *service) Walk(ctx context.Context, URL string, handler storage.OnVisit, options ...storage.Option) error {
 	if URL == "" {
 		return errors.New("URL was empty")
@@ -21,6 +22,10 @@ func (s *service) Walk(ctx context.Context, URL string, handler storage.OnVisit,
 	}
 	URL = url.Normalize(URL, file.Scheme)
 	managerWalker, ok := manager.(storage.Walker)
+	parallel := &option.Parallel{}
+	if _, has := option.Assign(options, &parallel); has && parallel.Workers > 1 {
+		ok = false //parallel walk lists and opens each entry independently
+	}
 	if ok {
 		return managerWalker.Walk(ctx, URL, handler, options...)
 	}
@@ -0,0 +1,53 @@
+package walker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//Error represents an error of listing, opening or visiting URL
+type Error struct {
+	URL string
+	Err error
+}
+
+//Error returns error message
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %v", e.URL, e.Err)
+}
+
+//Unwrap returns underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+//Errors represents walk errors
+type Errors []*Error
+
+//Sort sorts errors by URL
+func (e Errors) Sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].URL < e[j].URL
+	})
+}
+
+//Error returns errors message
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	var messages = make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%v walk errors: %v", len(e), strings.Join(messages, "; "))
+}
+
+//Unwrap returns the first error
+func (e Errors) Unwrap() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e[0]
+}
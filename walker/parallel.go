@@ -0,0 +1,191 @@
+package walker
+
+import (
+	"context"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+//task represents a folder listing (nil object) or an object visit
+type task struct {
+	object storage.Object
+	parent string
+}
+
+//parallelWalk represents bounded concurrency walk, workers share a task stack and stop once no task is pending or context is done
+type parallelWalk struct {
+	*walker
+	stop       context.CancelFunc
+	URL        string
+	handler    storage.OnVisit
+	options    []storage.Option
+	parallel   *option.Parallel
+	depth      *option.Depth
+	mux        sync.Mutex
+	cond       *sync.Cond
+	tasks      []*task
+	pending    int
+	cancelled  bool
+	errors     Errors
+	handlerMux sync.Mutex
+}
+
+func (p *parallelWalk) push(tasks ...*task) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.tasks = append(p.tasks, tasks...)
+	p.pending += len(tasks)
+	p.cond.Broadcast()
+}
+
+func (p *parallelWalk) next() (*task, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	for len(p.tasks) == 0 && p.pending > 0 && !p.cancelled {
+		p.cond.Wait()
+	}
+	if p.cancelled || len(p.tasks) == 0 {
+		return nil, false
+	}
+	last := len(p.tasks) - 1
+	result := p.tasks[last]
+	p.tasks = p.tasks[:last]
+	return result, true
+}
+
+func (p *parallelWalk) done(err *Error) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if err != nil {
+		p.errors = append(p.errors, err)
+	}
+	p.pending--
+	if p.pending == 0 {
+		p.cond.Broadcast()
+	}
+}
+
+func (p *parallelWalk) cancel() {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.cancelled = true
+	p.cond.Broadcast()
+}
+
+func (p *parallelWalk) work(ctx context.Context) {
+	for {
+		task, ok := p.next()
+		if !ok {
+			return
+		}
+		var err *Error
+		if ctx.Err() == nil {
+			if task.object == nil {
+				err = p.list(ctx, task.parent)
+			} else {
+				err = p.visit(ctx, task)
+			}
+		}
+		if err != nil {
+			p.stop()
+		}
+		p.done(err)
+	}
+}
+
+func (p *parallelWalk) list(ctx context.Context, parent string) *Error {
+	resourceURL := p.URL
+	if parent != "" {
+		resourceURL = url.Join(p.URL, parent)
+	}
+	objects, err := p.List(ctx, resourceURL, p.options...)
+	if err != nil {
+		return &Error{URL: resourceURL, Err: err}
+	}
+	var tasks = make([]*task, 0, len(objects))
+	for i := len(objects) - 1; i >= 0; i-- {
+		if objects[i].IsDir() && url.Equals(resourceURL, objects[i].URL()) {
+			continue
+		}
+		tasks = append(tasks, &task{object: objects[i], parent: parent})
+	}
+	p.push(tasks...)
+	return nil
+}
+
+func (p *parallelWalk) visit(ctx context.Context, entry *task) *Error {
+	object := entry.object
+	var reader io.ReadCloser
+	var err error
+	if !object.IsDir() {
+		if reader, err = p.Open(ctx, object, p.options...); err != nil {
+			return &Error{URL: object.URL(), Err: err}
+		}
+		defer func() { _ = reader.Close() }()
+	}
+	if !p.parallel.ConcurrentHandler {
+		p.handlerMux.Lock()
+	}
+	toContinue, err := p.handler(ctx, p.URL, entry.parent, object, reader)
+	if !p.parallel.ConcurrentHandler {
+		p.handlerMux.Unlock()
+	}
+	if err != nil {
+		return &Error{URL: object.URL(), Err: err}
+	}
+	if !toContinue && !object.IsDir() {
+		p.stop()
+	}
+	if !toContinue || !object.IsDir() {
+		return nil
+	}
+	relative := path.Join(entry.parent, object.Name())
+	if p.depth.Allows(strings.Count(relative, "/") + 1) {
+		p.push(&task{parent: relative})
+	}
+	return nil
+}
+
+//walkParallel traverses URL with bounded number of workers, an error or handler returning false for a file stops the walk,
+//errors of entries already in progress are returned sorted by URL
+func (w *walker) walkParallel(ctx context.Context, URL string, handler storage.OnVisit, options []storage.Option, parallel *option.Parallel, depth *option.Depth) error {
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	walk := &parallelWalk{
+		walker:   w,
+		stop:     cancel,
+		URL:      url.Normalize(URL, w.Scheme()),
+		handler:  handler,
+		options:  options,
+		parallel: parallel,
+		depth:    depth,
+	}
+	walk.cond = sync.NewCond(&walk.mux)
+	go func() {
+		<-walkCtx.Done()
+		walk.cancel()
+	}()
+	walk.push(&task{})
+	waitGroup := &sync.WaitGroup{}
+	for i := 0; i < parallel.Workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			walk.work(walkCtx)
+		}()
+	}
+	waitGroup.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(walk.errors) == 0 {
+		return nil
+	}
+	walk.errors.Sort()
+	return walk.errors
+}
@@ -0,0 +1,156 @@
+package walker_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/walker"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWalker_Walk(t *testing.T) {
+	ctx := context.Background()
+	manager := mem.New()
+	baseURL := "mem://localhost/parallel_walk"
+	for _, name := range []string{"a.txt", "b.txt", "d1/c.txt", "d1/d2/e.txt", "d1/d2/d3/f.txt", "d4/g.txt", "d4/h.txt"} {
+		if !assert.Nil(t, manager.Upload(ctx, baseURL+"/"+name, 0644, strings.NewReader(name))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description    string
+		options        []storage.Option
+		skipped        map[string]bool
+		expect         []string
+		maxConcurrency int32
+	}{
+		{
+			description: "sequential walk",
+			expect:      []string{"a.txt", "b.txt", "d1", "d1/c.txt", "d1/d2", "d1/d2/d3", "d1/d2/d3/f.txt", "d1/d2/e.txt", "d4", "d4/g.txt", "d4/h.txt"},
+		},
+		{
+			description:    "parallel walk",
+			options:        []storage.Option{option.NewParallel(4, false)},
+			expect:         []string{"a.txt", "b.txt", "d1", "d1/c.txt", "d1/d2", "d1/d2/d3", "d1/d2/d3/f.txt", "d1/d2/e.txt", "d4", "d4/g.txt", "d4/h.txt"},
+			maxConcurrency: 1,
+		},
+		{
+			description: "sequential walk with depth",
+			options:     []storage.Option{option.NewDepth(2)},
+			expect:      []string{"a.txt", "b.txt", "d1", "d1/c.txt", "d1/d2", "d4", "d4/g.txt", "d4/h.txt"},
+		},
+		{
+			description: "parallel walk with depth",
+			options:     []storage.Option{option.NewParallel(4, true), option.NewDepth(1)},
+			expect:      []string{"a.txt", "b.txt", "d1", "d4"},
+		},
+		{
+			description: "parallel walk with skipped directory",
+			options:     []storage.Option{option.NewParallel(3, true)},
+			skipped:     map[string]bool{"d1": true},
+			expect:      []string{"a.txt", "b.txt", "d1", "d4", "d4/g.txt", "d4/h.txt"},
+		},
+	}
+
+	for _, useCase := range useCases {
+		var actual []string
+		var mux sync.Mutex
+		var running, maxRunning int32
+		err := walker.New(manager).Walk(ctx, baseURL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			mux.Lock()
+			if current > maxRunning {
+				maxRunning = current
+			}
+			name := path.Join(parent, info.Name())
+			actual = append(actual, name)
+			mux.Unlock()
+			if !info.IsDir() {
+				data, err := ioutil.ReadAll(reader)
+				assert.Nil(t, err, useCase.description)
+				assert.EqualValues(t, name, string(data), useCase.description)
+			}
+			time.Sleep(time.Millisecond)
+			return !useCase.skipped[name], nil
+		}, useCase.options...)
+		sort.Strings(actual)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		if useCase.maxConcurrency > 0 {
+			assert.EqualValues(t, useCase.maxConcurrency, maxRunning, useCase.description)
+		}
+		assert.Nil(t, err, useCase.description)
+	}
+}
+
+func TestWalker_Walk_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := mem.New()
+	baseURL := "mem://localhost/parallel_walk_cancel"
+	for i := 0; i < 100; i++ {
+		if !assert.Nil(t, manager.Upload(ctx, baseURL+"/"+strings.Repeat("a", i+1), 0644, strings.NewReader("abc"))) {
+			return
+		}
+	}
+	var visited int32
+	err := walker.New(manager).Walk(ctx, baseURL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+		if atomic.AddInt32(&visited, 1) == 5 {
+			cancel()
+		}
+		return true, nil
+	}, option.NewParallel(2, false))
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, atomic.LoadInt32(&visited) < 100)
+}
+
+func TestWalker_Walk_Stop(t *testing.T) {
+	ctx := context.Background()
+	manager := mem.New()
+	baseURL := "mem://localhost/parallel_walk_stop"
+	for i := 0; i < 100; i++ {
+		if !assert.Nil(t, manager.Upload(ctx, baseURL+"/"+strings.Repeat("a", i+1), 0644, strings.NewReader("abc"))) {
+			return
+		}
+	}
+
+	var useCases = []struct {
+		description string
+		err         error
+		hasError    bool
+	}{
+		{description: "handler returning false stops walk"},
+		{description: "handler error stops walk", err: errors.New("test error"), hasError: true},
+	}
+	for _, useCase := range useCases {
+		var visited int32
+		err := walker.New(manager).Walk(ctx, baseURL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (bool, error) {
+			if atomic.AddInt32(&visited, 1) == 5 {
+				return false, useCase.err
+			}
+			return true, nil
+		}, option.NewParallel(2, false))
+		if useCase.hasError {
+			walkErrors, ok := err.(walker.Errors)
+			if assert.True(t, ok, useCase.description) {
+				assert.EqualValues(t, 1, len(walkErrors), useCase.description)
+			}
+		} else {
+			assert.Nil(t, err, useCase.description)
+		}
+		assert.True(t, atomic.LoadInt32(&visited) < 10, useCase.description)
+	}
+}
@@ -3,10 +3,12 @@ package walker
 import (
 	"context"
 	"github.com/pkg/errors"
+	"github.com/viant/afs/option"
 	"github.com/viant/afs/storage"
 	"github.com/viant/afs/url"
 	"io"
 	"path"
+	"strings"
 )
 
 type walker struct {
@@ -14,11 +16,19 @@ type walker struct {
 	counter      uint32
 	locationName string
 	parent string
+	depth  *option.Depth
 }
 
-//Walk traverses URL and calls handler on all file or folder
+//Walk traverses URL and calls handler on all file or folder, option.Parallel with more than one worker enables parallel walk
 func (w *walker) Walk(ctx context.Context, URL string, handler storage.OnVisit, options ...storage.Option) error {
+	depth := &option.Depth{}
+	option.Assign(options, &depth)
+	parallel := &option.Parallel{}
+	if _, ok := option.Assign(options, &parallel); ok && parallel.Workers > 1 {
+		return w.walkParallel(ctx, URL, handler, options, parallel, depth)
+	}
 	w.counter = 0
+	w.depth = depth
 	_, URLPath := url.Base(URL, w.Manager.Scheme())
 	w.parent, w.locationName = path.Split(URLPath)
 	return w.walk(ctx, URL, "", handler, options)
@@ -51,6 +61,9 @@ func (w *walker) visitResource(ctx context.Context, object storage.Object, URL,
 	if parent != "" {
 		relative = path.Join(parent, object.Name())
 	}
+	if !w.depth.Allows(strings.Count(relative, "/") + 1) {
+		return nil
+	}
 	if err = w.walk(ctx, URL, relative, handler, options); err != nil {
 		return err
 	}
@@ -0,0 +1,134 @@
+package zip_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+	"github.com/viant/afs/option"
+	"github.com/viant/afs/storage"
+	"path"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestManager_ListIter(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	baseDir, _ := path.Split(filename)
+	ctx := context.Background()
+
+	var useCases = []struct {
+		description string
+		URL         string
+		pageSize    int
+		expect      []string
+	}{
+		{
+			description: "iterate war classes",
+			URL:         fmt.Sprintf("file:%v/test/app.war/zip://localhost/WEB-INF/classes", baseDir),
+			pageSize:    10,
+			expect:      []string{"HelloWorld.class", "config.properties"},
+		},
+		{
+			description: "iterate war classes with continuation",
+			URL:         fmt.Sprintf("file:%v/test/app.war/zip://localhost/WEB-INF/classes", baseDir),
+			pageSize:    1,
+			expect:      []string{"HelloWorld.class", "config.properties"},
+		},
+		{
+			description: "iterate war file",
+			URL:         fmt.Sprintf("file:%v/test/app.war/zip://localhost/WEB-INF/classes/config.properties", baseDir),
+			pageSize:    1,
+			expect:      []string{"config.properties"},
+		},
+	}
+
+	for _, useCase := range useCases {
+		service := afs.New()
+		var actual []string
+		token := ""
+		for i := 0; i < 10; i++ {
+			iter, err := service.ListIter(ctx, useCase.URL, option.NewContinuation(token))
+			if !assert.Nil(t, err, useCase.description) {
+				break
+			}
+			names, next := iterate(iter, useCase.pageSize)
+			assert.Nil(t, iter.Err(), useCase.description)
+			assert.Nil(t, iter.Close(), useCase.description)
+			actual = append(actual, names...)
+			if len(names) < useCase.pageSize {
+				break
+			}
+			token = next
+		}
+		sort.Strings(actual)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestManager_ListIter_List(t *testing.T) {
+	ctx := context.Background()
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for _, name := range []string{"a.txt", "sub/b.txt", "sub/deep/c.txt"} {
+		entryWriter, err := writer.Create(name)
+		if !assert.Nil(t, err) {
+			return
+		}
+		_, _ = entryWriter.Write([]byte(name))
+	}
+	assert.Nil(t, writer.Close())
+	service := afs.New()
+	archiveURL := "mem://localhost/iter/nested.zip"
+	if !assert.Nil(t, service.Upload(ctx, archiveURL, 0644, bytes.NewReader(buffer.Bytes()))) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+	}{
+		{description: "root", location: "", expect: []string{"a.txt", "sub"}},
+		{description: "folder", location: "sub", expect: []string{"b.txt", "deep"}},
+		{description: "nested folder", location: "sub/deep", expect: []string{"c.txt"}},
+	}
+	for _, useCase := range useCases {
+		URL := "mem:localhost/iter/nested.zip/zip://localhost/" + useCase.location
+		objects, err := service.List(ctx, URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var listed []string
+		for _, object := range objects {
+			if object.IsDir() && strings.Trim(object.URL(), "/") == strings.Trim(URL, "/") {
+				continue
+			}
+			listed = append(listed, object.Name())
+		}
+		iter, err := service.ListIter(ctx, URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		iterated, _ := iterate(iter, 100)
+		assert.Nil(t, iter.Close(), useCase.description)
+		sort.Strings(listed)
+		sort.Strings(iterated)
+		assert.EqualValues(t, useCase.expect, listed, useCase.description)
+		assert.EqualValues(t, listed, iterated, useCase.description)
+	}
+}
+
+func iterate(iter storage.Iterator, limit int) ([]string, string) {
+	var names []string
+	token := ""
+	for len(names) < limit && iter.Next() {
+		names = append(names, iter.Object().Name())
+		token = iter.Token()
+	}
+	return names, token
+}
@@ -57,6 +57,24 @@ func (m *manager) Uploader(ctx context.Context, URL string, options ...storage.O
 	return service.Uploader(ctx, URLPath)
 }
 
+//Move moves entries within the same archive, unchanged entries are copied without decompression
+func (m *manager) Move(ctx context.Context, sourceURL, destURL string, options ...storage.Option) error {
+	sourceBaseURL, sourcePath := url.Base(sourceURL, Scheme)
+	destBaseURL, destPath := url.Base(destURL, Scheme)
+	if url.SchemeExtensionURL(sourceBaseURL) != url.SchemeExtensionURL(destBaseURL) {
+		return fmt.Errorf("unable to move: %v to %v, entries can be only moved within the same archive", sourceURL, destURL)
+	}
+	srv, err := m.Storager(ctx, sourceBaseURL, options)
+	if err != nil {
+		return err
+	}
+	service, ok := srv.(*storager)
+	if !ok {
+		return fmt.Errorf("unsupported storager type: expected: %T, but had %T", service, srv)
+	}
+	return service.Move(ctx, sourcePath, destPath)
+}
+
 func newManager(options ...storage.Option) *manager {
 	result := &manager{}
 	baseMgr := base.New(result, Scheme, result.provider, options)
@@ -0,0 +1,81 @@
+package zip
+
+import (
+	"archive/zip"
+	"context"
+	"github.com/viant/afs/archive"
+	"io"
+	"strings"
+)
+
+//modify applies changes in a single archive pass, unchanged and moved entries are raw copied without recompression
+func (s *storager) modify(ctx context.Context, changes *archive.Changes) error {
+	err := archive.Modify(ctx, s.manager, s.URL, s.mode, func(base io.ReaderAt, size int64, writer io.Writer) error {
+		return rewrite(base, size, writer, changes)
+	})
+	s.walker.data = nil
+	return err
+}
+
+func rewrite(base io.ReaderAt, size int64, dest io.Writer, changes *archive.Changes) error {
+	writer := zip.NewWriter(dest)
+	existing := make(map[string]bool)
+	if size > 0 {
+		reader, err := zip.NewReader(base, size)
+		if err != nil {
+			return err
+		}
+		for _, entry := range reader.File {
+			name, ok := changes.Rename(entry.Name)
+			if !ok {
+				continue
+			}
+			existing[name] = true
+			if err = copyEntry(writer, entry, name); err != nil {
+				return err
+			}
+		}
+	}
+	if err := changes.Validate(); err != nil {
+		return err
+	}
+	for _, resource := range changes.Additions(existing) {
+		header, err := newHeader(resource.Name, resource.Info())
+		if err != nil {
+			return err
+		}
+		entryWriter, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if !resource.Dir {
+			content, _ := changes.Content(resource)
+			if _, err = io.Copy(entryWriter, content); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Close()
+}
+
+//copyEntry copies compressed entry, entry is renamed if name has changed
+func copyEntry(writer *zip.Writer, entry *zip.File, name string) error {
+	if name == strings.Trim(entry.Name, "/") {
+		return writer.Copy(entry)
+	}
+	header := entry.FileHeader
+	header.Name = name
+	if strings.HasSuffix(entry.Name, "/") {
+		header.Name += "/"
+	}
+	reader, err := entry.OpenRaw()
+	if err != nil {
+		return err
+	}
+	entryWriter, err := writer.CreateRaw(&header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entryWriter, reader)
+	return err
+}
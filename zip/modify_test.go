@@ -0,0 +1,152 @@
+package zip
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/mem"
+	"github.com/viant/afs/storage"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func newTestArchive(t *testing.T) []byte {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for _, header := range []*zip.FileHeader{
+		{Name: "folder1/", Method: zip.Store},
+		{Name: "folder1/res1.txt", Method: zip.Deflate},
+		{Name: "folder1/res2.txt", Method: zip.Store},
+		{Name: "res3.txt", Method: zip.Deflate},
+	} {
+		entryWriter, err := writer.CreateHeader(header)
+		assert.Nil(t, err)
+		if !strings.HasSuffix(header.Name, "/") {
+			_, err = entryWriter.Write([]byte(strings.Repeat("this is test "+header.Name, 10)))
+			assert.Nil(t, err)
+		}
+	}
+	assert.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestStorager_Modify(t *testing.T) {
+	ctx := context.Background()
+	tempDir := os.TempDir()
+
+	var useCases = []struct {
+		description string
+		manager     storage.Manager
+		archiveURL  string
+		modify      func(storager *storager) error
+		expect      map[string]string
+		hasError    bool
+	}{
+		{
+			description: "delete entry in place",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_zip_modify_001.zip"),
+			modify: func(storager *storager) error {
+				return storager.Delete(ctx, "folder1/res1.txt")
+			},
+			expect: map[string]string{"folder1/": "", "folder1/res2.txt": "", "res3.txt": ""},
+		},
+		{
+			description: "move folder",
+			manager:     mem.New(),
+			archiveURL:  "mem://localhost/data/afs_zip_modify_002.zip",
+			modify: func(storager *storager) error {
+				return storager.Move(ctx, "folder1", "folder2/sub")
+			},
+			expect: map[string]string{"folder2/sub/": "folder1/", "folder2/sub/res1.txt": "folder1/res1.txt", "folder2/sub/res2.txt": "folder1/res2.txt", "res3.txt": "", "folder2/": ""},
+		},
+		{
+			description: "append entry",
+			manager:     file.New(),
+			archiveURL:  path.Join(tempDir, "afs_zip_modify_003.zip"),
+			modify: func(storager *storager) error {
+				return storager.Upload(ctx, "folder1/res4.txt", 0644, strings.NewReader("this is test 4"))
+			},
+			expect: map[string]string{"folder1/": "", "folder1/res1.txt": "", "folder1/res2.txt": "", "res3.txt": "", "folder1/res4.txt": "this is test 4"},
+		},
+		{
+			description: "move missing entry",
+			manager:     mem.New(),
+			archiveURL:  "mem://localhost/data/afs_zip_modify_004.zip",
+			modify: func(storager *storager) error {
+				return storager.Move(ctx, "folder3", "folder4")
+			},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		original := newTestArchive(t)
+		err := useCase.manager.Upload(ctx, useCase.archiveURL, 0644, bytes.NewReader(original))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		baseURL := strings.Replace(useCase.archiveURL, "://", ":", 1) + "/zip://localhost/"
+		if !strings.Contains(useCase.archiveURL, "://") {
+			baseURL = "file:" + useCase.archiveURL + "/zip://localhost/"
+		}
+		storager, err := newStorager(ctx, baseURL, useCase.manager)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		err = useCase.modify(storager)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		reader, err := useCase.manager.OpenURL(ctx, useCase.archiveURL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		data, _ := ioutil.ReadAll(reader)
+		_ = reader.Close()
+		originalReader, err := zip.NewReader(bytes.NewReader(original), int64(len(original)))
+		assert.Nil(t, err, useCase.description)
+		originalEntries := map[string]*zip.File{}
+		for _, entry := range originalReader.File {
+			originalEntries[entry.Name] = entry
+		}
+		modifiedReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.Equal(t, len(useCase.expect), len(modifiedReader.File), useCase.description)
+		for _, entry := range modifiedReader.File {
+			source, ok := useCase.expect[entry.Name]
+			if !assert.True(t, ok, useCase.description+" "+entry.Name) {
+				continue
+			}
+			if source == "" {
+				source = entry.Name
+			}
+			originalEntry, ok := originalEntries[source]
+			if !ok { //appended entry
+				if entry.FileInfo().IsDir() {
+					continue
+				}
+				entryReader, err := entry.Open()
+				assert.Nil(t, err, useCase.description)
+				content, _ := ioutil.ReadAll(entryReader)
+				assert.Equal(t, source, string(content), useCase.description)
+				continue
+			}
+			assert.Equal(t, originalEntry.Method, entry.Method, useCase.description+" "+entry.Name)
+			assert.Equal(t, originalEntry.CompressedSize64, entry.CompressedSize64, useCase.description+" "+entry.Name)
+			assert.Equal(t, originalEntry.CRC32, entry.CRC32, useCase.description+" "+entry.Name)
+		}
+		_ = useCase.manager.Delete(ctx, useCase.archiveURL)
+	}
+}
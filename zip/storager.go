@@ -31,6 +31,7 @@ type storager struct {
 	closer     io.Closer
 	uploader   storage.Uploader
 	downloader storage.Opener
+	manager    storage.Manager
 }
 
 //Exists returns true if resource exists in archive
@@ -46,23 +47,21 @@ func (s *storager) List(ctx context.Context, location string, options ...storage
 	}
 	var result = make([]os.FileInfo, 0)
 	location = strings.Trim(location, "/")
-	basicMatcher, _ := matcher.NewBasic(location, "", "", nil)
+	listing := archive.NewListing(location)
 
 	match, page := option.GetListOptions(options)
 
 	err := s.walker.Walk(ctx, s.URL, func(ctx context.Context, baseURL string, parent string, info os.FileInfo, reader io.Reader) (toContinue bool, err error) {
-		if !basicMatcher.Match(parent, info) {
-			return true, nil
-		}
-		page.Increment()
-		if page.ShallSkip() {
-			return true, nil
-		}
-
-		if !match(parent, info) {
-			return true, nil
+		for _, entry := range listing.Entries(parent, info) {
+			page.Increment()
+			if page.ShallSkip() {
+				continue
+			}
+			if !match(entry.Parent, entry.FileInfo) {
+				continue
+			}
+			result = append(result, entry.FileInfo)
 		}
-		result = append(result, info)
 		return true, nil
 	})
 	return result, err
@@ -124,21 +123,25 @@ func (s *storager) Delete(ctx context.Context, location string, options ...stora
 	if !s.exists {
 		return fmt.Errorf("%v: not found", s.URL)
 	}
-	location = strings.Trim(location, "/")
-	uploader := newBatchUploader(nil)
-	upload, closer, err := uploader.Uploader(ctx, "")
-	if err != nil {
+	changes := archive.NewChanges()
+	changes.Delete(location)
+	if err := s.modify(ctx, changes); err != nil {
 		return errors.Wrapf(err, "failed to delete: %v in archive: %v", location, s.URL)
 	}
+	return nil
+}
 
-	err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.DeleteHandler(location))
-	if err == nil {
-		err = closer.Close()
+//Move moves specified resource within archive
+func (s *storager) Move(ctx context.Context, source, dest string, options ...storage.Option) error {
+	if !s.exists {
+		return fmt.Errorf("%v: not found", s.URL)
 	}
-	if err != nil {
-		return err
+	changes := archive.NewChanges()
+	changes.Move(source, dest)
+	if err := s.modify(ctx, changes); err != nil {
+		return errors.Wrapf(err, "failed to move: %v to %v in archive: %v", source, dest, s.URL)
 	}
-	return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+	return nil
 }
 
 func (s *storager) touch(ctx context.Context) error {
@@ -161,22 +164,11 @@ func (s *storager) Uploader(ctx context.Context, destination string) (storage.Up
 		}
 	}
 	destination = strings.Trim(destination, "/")
-	uploader := archive.NewRewriteUploader(func(resources []*asset.Resource) error {
-		uploader := newBatchUploader(nil)
-		upload, closer, err := uploader.Uploader(ctx, "")
-		if err != nil {
+	uploader := archive.NewSpoolUploader(destination, func(changes *archive.Changes) error {
+		if err := s.modify(ctx, changes); err != nil {
 			return errors.Wrapf(err, "failed to upload: %v in archive: %v", destination, s.URL)
 		}
-		resources = archive.UpdateDestination(destination, resources)
-		err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.UploadHandler(resources))
-		if err == nil {
-			err = closer.Close()
-		}
-		if err != nil {
-			return err
-		}
-		s.walker.data = uploader.buffer.Bytes()
-		return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+		return nil
 	})
 	return uploader.Upload, uploader, nil
 }
@@ -194,26 +186,19 @@ func (s *storager) Create(ctx context.Context, destination string, mode os.FileM
 		}
 	}
 	destination = strings.Trim(destination, "/")
-	uploader := newBatchUploader(nil)
-	upload, closer, err := uploader.Uploader(ctx, "")
-	if err != nil {
-		return errors.Wrapf(err, "failed to create: %v in archive: %v", destination, s.URL)
-	}
 	var content []byte
+	var err error
 	if reader != nil {
 		if content, err = ioutil.ReadAll(reader); err != nil {
 			return err
 		}
 	}
-	err = archive.Rewrite(ctx, s.walker, s.URL, upload, archive.CreateHandler(destination, mode, content, isDir))
-	if err == nil {
-		err = closer.Close()
-	}
-	if err != nil {
-		return err
+	changes := archive.NewChanges()
+	changes.Add(asset.New(destination, mode, isDir, "", content))
+	if err = s.modify(ctx, changes); err != nil {
+		return errors.Wrapf(err, "failed to create: %v in archive: %v", destination, s.URL)
 	}
-	s.walker.data = uploader.buffer.Bytes()
-	return s.uploader.Upload(ctx, s.URL, s.mode, uploader.buffer)
+	return nil
 }
 
 //Close closes undelrying closer
@@ -243,6 +228,7 @@ func newStorager(ctx context.Context, baseURL string, mgr storage.Manager) (*sto
 		uploader:   mgr,
 		mode:       mode,
 		downloader: mgr,
+		manager:    mgr,
 		URL:        URL,
 	}
 	result.Storager.List = result.List
@@ -1,16 +1,20 @@
 package zip
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/afs/asset"
+	"github.com/viant/afs/file"
 	"github.com/viant/afs/mem"
 	"github.com/viant/afs/storage"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 	"testing"
 )
 
@@ -101,3 +105,80 @@ func TestNewStorager(t *testing.T) {
 	}
 
 }
+
+func TestStorager_List(t *testing.T) {
+	ctx := context.Background()
+	baseDir, _ := os.Getwd()
+	storager, err := newStorager(ctx, fmt.Sprintf("file:%v/test/test.%v/%v://localhost/", baseDir, Scheme, Scheme), file.New())
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+	}{
+		{description: "root", location: "", expect: []string{"test"}},
+		{description: "folder direct children", location: "test", expect: []string{"", "asset1.txt", "asset2.txt", "folder1", "folder2"}},
+		{description: "nested folder", location: "test/folder2", expect: []string{"", "res1.txt"}},
+		{description: "file", location: "test/asset1.txt", expect: []string{"asset1.txt"}},
+	}
+	for _, useCase := range useCases {
+		objects, err := storager.List(ctx, useCase.location)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual []string
+		for _, object := range objects {
+			actual = append(actual, object.Name())
+		}
+		sort.Strings(actual)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestStorager_List_ImplicitDirectories(t *testing.T) {
+	ctx := context.Background()
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for _, name := range []string{"a.txt", "sub/b.txt", "sub/deep/c.txt"} {
+		entryWriter, err := writer.Create(name)
+		if !assert.Nil(t, err) {
+			return
+		}
+		_, _ = entryWriter.Write([]byte(name))
+	}
+	assert.Nil(t, writer.Close())
+	manager := mem.New()
+	URL := "mem://localhost/zip/implicit.zip"
+	if !assert.Nil(t, manager.Upload(ctx, URL, 0644, bytes.NewReader(buffer.Bytes()))) {
+		return
+	}
+	storager, err := newStorager(ctx, "mem:localhost/zip/implicit.zip/zip://localhost/", manager)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		location    string
+		expect      []string
+	}{
+		{description: "root", location: "", expect: []string{"a.txt", "sub"}},
+		{description: "implicit folder", location: "sub", expect: []string{"b.txt", "deep", "sub"}},
+		{description: "nested implicit folder", location: "sub/deep", expect: []string{"c.txt", "deep"}},
+	}
+	for _, useCase := range useCases {
+		objects, err := storager.List(ctx, useCase.location)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual []string
+		for _, object := range objects {
+			actual = append(actual, object.Name())
+		}
+		sort.Strings(actual)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
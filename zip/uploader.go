@@ -29,21 +29,10 @@ func (u *uploader) Uploader(ctx context.Context, URL string, options ...storage.
 	}
 	writer := newWriter(ctx, u.buffer, URL, uploader)
 	return func(ctx context.Context, parent string, info os.FileInfo, reader io.Reader) error {
-		filename := path.Join(parent, info.Name())
-		mode := info.Mode().Perm()
-		if info.IsDir() {
-			mode |= os.ModeDir
-		}
-		info = file.NewInfo(filename, info.Size(), mode, info.ModTime(), info.IsDir())
-		header, err := zip.FileInfoHeader(info)
+		header, err := newHeader(path.Join(parent, info.Name()), info)
 		if err != nil {
 			return err
 		}
-		header.Method = zip.Deflate
-		header.Name = filename
-		if info.IsDir() && !strings.HasSuffix(filename, "/") {
-			header.Name += "/"
-		}
 		writer, err := writer.CreateHeader(header)
 		if reader != nil {
 			_, err = io.Copy(writer, reader)
@@ -55,6 +44,25 @@ func (u *uploader) Uploader(ctx context.Context, URL string, options ...storage.
 	}, writer, nil
 }
 
+//newHeader returns deflate zip header for supplied archive filename
+func newHeader(filename string, info os.FileInfo) (*zip.FileHeader, error) {
+	mode := info.Mode().Perm()
+	if info.IsDir() {
+		mode |= os.ModeDir
+	}
+	info = file.NewInfo(filename, info.Size(), mode, info.ModTime(), info.IsDir())
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return nil, err
+	}
+	header.Method = zip.Deflate
+	header.Name = filename
+	if info.IsDir() && !strings.HasSuffix(filename, "/") {
+		header.Name += "/"
+	}
+	return header, nil
+}
+
 //newBatchUploader returns a batch uploader
 func newBatchUploader(dest storage.Uploader) *uploader {
 	return &uploader{uploader: dest}